@@ -0,0 +1,58 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Map composes a BinderFunc, which transforms the value constructed by
+// binder through transform, like wrapping a client with instrumentation.
+func Map(binder BinderFunc, transform func(value any) any) BinderFunc {
+	return func(resolver ResolverFunc) any {
+		return transform(binder(resolver))
+	}
+}
+
+// Chain composes a BinderFunc, which feeds the value constructed by binder
+// into next. Unlike Map, next receives the ResolverFunc of the resolution,
+// so it may resolve additional dependencies to build on the value.
+func Chain(binder BinderFunc, next func(value any, resolver ResolverFunc) any) BinderFunc {
+	return func(resolver ResolverFunc) any {
+		return next(binder(resolver), resolver)
+	}
+}
+
+// WithConcurrencyLimit guards binder by a semaphore, so at most n
+// constructions run concurrently, while further resolutions wait for a
+// running one to finish. It protects expensive instanced bindings, like
+// ones spawning subprocesses, from a stampede of concurrent resolutions.
+// Binders must not resolve their own binding, as they would wait for
+// themselves once the limit is reached. WithConcurrencyLimit panics, if n
+// isn't positive.
+func WithConcurrencyLimit(binder BinderFunc, n int) BinderFunc {
+	if n <= 0 {
+		panic(errors.New(fmt.Sprintf("concurrency limit %d must be positive", n)))
+	}
+	slots := make(chan struct{}, n)
+	return func(resolver ResolverFunc) any {
+		slots <- struct{}{}
+		defer func() {
+			<-slots
+		}()
+		return binder(resolver)
+	}
+}
+
+// Serialized guards binder by a mutex, so it is never invoked concurrently.
+// It makes legacy binders safe, which mutate state captured by their
+// closure, like a counter, once they are resolved concurrently. Like with
+// WithConcurrencyLimit, binders must not resolve their own binding.
+func Serialized(binder BinderFunc) BinderFunc {
+	var mu sync.Mutex
+	return func(resolver ResolverFunc) any {
+		mu.Lock()
+		defer mu.Unlock()
+		return binder(resolver)
+	}
+}
@@ -0,0 +1,101 @@
+package godi
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("greeting", Map(func(resolver ResolverFunc) any {
+		return "hello"
+	}, func(value any) any {
+		return strings.ToUpper(value.(string))
+	}))
+	if v := MustResolve[string]("greeting", container.Resolver()); v != "HELLO" {
+		t.Fatalf("Value not transformed: %s", v)
+	}
+}
+
+func TestChain(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("name", func(resolver ResolverFunc) any {
+		return "world"
+	})
+	container.MustBind("greeting", Chain(func(resolver ResolverFunc) any {
+		return "hello"
+	}, func(value any, resolver ResolverFunc) any {
+		return value.(string) + " " + MustResolve[string]("name", resolver)
+	}))
+	if v := MustResolve[string]("greeting", container.Resolver()); v != "hello world" {
+		t.Fatalf("Value not chained: %s", v)
+	}
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	container := NewContainer()
+	container.MustBind("worker", WithConcurrencyLimit(func(resolver ResolverFunc) any {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return "worker"
+	}, 2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			MustResolve[string]("worker", container.Resolver())
+		}()
+	}
+	wg.Wait()
+	if peak.Load() > 2 {
+		t.Fatalf("Concurrency limit exceeded: %d constructions ran concurrently", peak.Load())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Non-positive concurrency limit accepted")
+		}
+	}()
+	WithConcurrencyLimit(nil, 0)
+}
+
+func TestSerialized(t *testing.T) {
+	container := NewContainer()
+	var num = 10
+	container.MustBind("rand", Serialized(func(resolver ResolverFunc) any {
+		num *= 10
+		value := num
+		return value
+	}))
+
+	var wg sync.WaitGroup
+	values := make([]int, 6)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i] = MustResolve[int]("rand", container.Resolver())
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[int]bool, len(values))
+	for _, v := range values {
+		if seen[v] {
+			t.Fatalf("Binder invoked concurrently: %v", values)
+		}
+		seen[v] = true
+	}
+}
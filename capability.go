@@ -0,0 +1,169 @@
+package godi
+
+import (
+	"context"
+)
+
+// Introspector inspects the bindings of a Container and the instances
+// they constructed.
+//
+// Introspector is one of the capabilities, optional interfaces a Container
+// may implement besides the Container interface. New features are added as
+// capabilities instead of growing the Container interface, so third-party
+// implementations of Container, like decorators, keep compiling and simply
+// lack the feature. The Container created by NewContainer implements all
+// capabilities. Callers discover them through the As helpers:
+//
+//	if introspector, ok := godi.AsIntrospector(c); ok {
+//		fmt.Println(introspector.Names())
+//	}
+type Introspector interface {
+	Names() []string
+	Info(name string) (BindingInfo, error)
+	Explain(name string) (*Tree, error)
+	Stats() map[string]BindingStats
+	Instances() []InstanceInfo
+	InstanceOf(value any) (InstanceInfo, bool)
+}
+
+// Closer shuts a Container down, draining it and closing its singletons,
+// or restarts it with freshly constructed singletons.
+type Closer interface {
+	Drain()
+	Close(ctx context.Context) error
+	Restart(ctx context.Context) error
+}
+
+// Warmer constructs the eager singletons of a Container ahead of their
+// first request.
+type Warmer interface {
+	Warmup(ctx context.Context) (WarmupReport, error)
+}
+
+// Freezer makes a Container immutable.
+type Freezer interface {
+	Freeze()
+}
+
+// Cloner copies a Container with all of its bindings.
+type Cloner interface {
+	Clone() Container
+}
+
+// Composer assembles a Container from the bindings of ProviderSets,
+// Modules and other Containers.
+type Composer interface {
+	CopyTo(dst Container, names ...string) error
+	Apply(set ProviderSet) error
+	Mount(prefix string, other Container) error
+	Install(modules ...Module) error
+}
+
+// Annotator attaches metadata to the bindings of a Container.
+type Annotator interface {
+	Deprecate(name, message, replacement string) error
+	Share(name string) error
+}
+
+// Handler creates pre-resolved Handles of the dependencies of a Container.
+type Handler interface {
+	Handle(name string) (Handle, error)
+}
+
+// ExtendedBinder binds dependencies, which are constructed by a Pool,
+// fetched from a SecretsProvider or routed to canary implementations.
+type ExtendedBinder interface {
+	BindPool(name string, size int, binder BinderFunc) error
+	BindSecret(name, key string) error
+	BindCanary(name string, percent int, stable, canary BinderFunc) (*Canary, error)
+}
+
+// Swapper exchanges bindings and the instances of singletons of a running
+// Container.
+type Swapper interface {
+	Swap(name string, binder BinderFunc) error
+	ResetSingleton(name string) error
+	Reload(name string) error
+}
+
+// Scoper creates Scopes of a Container.
+type Scoper interface {
+	NewScope(options ...ScopeOption) Scope
+}
+
+// AsIntrospector returns the Introspector capability of the Container, if
+// it implements it.
+func AsIntrospector(c Container) (Introspector, bool) {
+	i, ok := c.(Introspector)
+	return i, ok
+}
+
+// AsCloser returns the Closer capability of the Container, if it
+// implements it.
+func AsCloser(c Container) (Closer, bool) {
+	closer, ok := c.(Closer)
+	return closer, ok
+}
+
+// AsSwapper returns the Swapper capability of the Container, if it
+// implements it.
+func AsSwapper(c Container) (Swapper, bool) {
+	s, ok := c.(Swapper)
+	return s, ok
+}
+
+// AsScoper returns the Scoper capability of the Container, if it
+// implements it.
+func AsScoper(c Container) (Scoper, bool) {
+	s, ok := c.(Scoper)
+	return s, ok
+}
+
+// AsWarmer returns the Warmer capability of the Container, if it
+// implements it.
+func AsWarmer(c Container) (Warmer, bool) {
+	w, ok := c.(Warmer)
+	return w, ok
+}
+
+// AsFreezer returns the Freezer capability of the Container, if it
+// implements it.
+func AsFreezer(c Container) (Freezer, bool) {
+	f, ok := c.(Freezer)
+	return f, ok
+}
+
+// AsCloner returns the Cloner capability of the Container, if it
+// implements it.
+func AsCloner(c Container) (Cloner, bool) {
+	cloner, ok := c.(Cloner)
+	return cloner, ok
+}
+
+// AsComposer returns the Composer capability of the Container, if it
+// implements it.
+func AsComposer(c Container) (Composer, bool) {
+	composer, ok := c.(Composer)
+	return composer, ok
+}
+
+// AsAnnotator returns the Annotator capability of the Container, if it
+// implements it.
+func AsAnnotator(c Container) (Annotator, bool) {
+	a, ok := c.(Annotator)
+	return a, ok
+}
+
+// AsHandler returns the Handler capability of the Container, if it
+// implements it.
+func AsHandler(c Container) (Handler, bool) {
+	h, ok := c.(Handler)
+	return h, ok
+}
+
+// AsExtendedBinder returns the ExtendedBinder capability of the Container,
+// if it implements it.
+func AsExtendedBinder(c Container) (ExtendedBinder, bool) {
+	b, ok := c.(ExtendedBinder)
+	return b, ok
+}
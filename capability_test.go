@@ -0,0 +1,123 @@
+package godi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("config", func(resolver ResolverFunc) any {
+		return "config"
+	})
+
+	introspector, ok := AsIntrospector(container)
+	if !ok || len(introspector.Names()) != 2 {
+		t.Fatalf("Introspector capability not implemented")
+	}
+	swapper, ok := AsSwapper(container)
+	if !ok {
+		t.Fatalf("Swapper capability not implemented")
+	}
+	if err := swapper.Swap("config", func(resolver ResolverFunc) any {
+		return "swapped"
+	}); err != nil {
+		t.Fatalf("Unable to swap binding: %s", err)
+	}
+	scoper, ok := AsScoper(container)
+	if !ok {
+		t.Fatalf("Scoper capability not implemented")
+	}
+	scope := scoper.NewScope()
+	if v := MustResolve[string]("config", scope.Resolver()); v != "swapped" {
+		t.Fatalf("Unexpected value %s", v)
+	}
+	_ = scope.Close()
+	for capability, ok := range map[string]bool{
+		"Warmer":         implements(AsWarmer, container),
+		"Freezer":        implements(AsFreezer, container),
+		"Cloner":         implements(AsCloner, container),
+		"Composer":       implements(AsComposer, container),
+		"Annotator":      implements(AsAnnotator, container),
+		"Handler":        implements(AsHandler, container),
+		"ExtendedBinder": implements(AsExtendedBinder, container),
+	} {
+		if !ok {
+			t.Fatalf("%s capability not implemented", capability)
+		}
+	}
+	closer, ok := AsCloser(container)
+	if !ok || closer.Close(context.Background()) != nil {
+		t.Fatalf("Closer capability not implemented")
+	}
+}
+
+// implements reports, whether the As helper finds the capability.
+func implements[T any](as func(c Container) (T, bool), c Container) bool {
+	_, ok := as(c)
+	return ok
+}
+
+// foreignContainer is a minimal third-party Container, which implements
+// none of the capabilities.
+type foreignContainer struct {
+	Container
+}
+
+func TestCapabilities_Missing(t *testing.T) {
+	inner := NewContainer()
+	inner.MustBind("config", func(resolver ResolverFunc) any {
+		return "config"
+	})
+	container := foreignContainer{Container: inner}
+
+	if _, ok := AsIntrospector(container); ok {
+		t.Fatalf("Introspector capability reported for foreign container")
+	}
+	if _, ok := AsCloser(container); ok {
+		t.Fatalf("Closer capability reported for foreign container")
+	}
+	if _, ok := AsSwapper(container); ok {
+		t.Fatalf("Swapper capability reported for foreign container")
+	}
+	if _, ok := AsScoper(container); ok {
+		t.Fatalf("Scoper capability reported for foreign container")
+	}
+	for capability, ok := range map[string]bool{
+		"Warmer":         implements(AsWarmer, container),
+		"Freezer":        implements(AsFreezer, container),
+		"Cloner":         implements(AsCloner, container),
+		"Composer":       implements(AsComposer, container),
+		"Annotator":      implements(AsAnnotator, container),
+		"Handler":        implements(AsHandler, container),
+		"ExtendedBinder": implements(AsExtendedBinder, container),
+	} {
+		if ok {
+			t.Fatalf("%s capability reported for foreign container", capability)
+		}
+	}
+
+	if description := Describe(container); len(description.Bindings) != 0 {
+		t.Fatalf("Described foreign container: %+v", description)
+	}
+	if qualifiers := Qualifiers(container, "config"); qualifiers != nil {
+		t.Fatalf("Reported qualifiers of foreign container: %v", qualifiers)
+	}
+	if _, err := ResolveWhere(container, func(info BindingInfo) bool { return true }); err == nil {
+		t.Fatalf("Inspected bindings of foreign container")
+	}
+	if err := swap(container, "config", func(resolver ResolverFunc) any { return "swapped" }); err == nil {
+		t.Fatalf("Swapped binding of foreign container")
+	}
+
+	parent := NewContainer().(*defaultContainer)
+	if err := parent.Mount("foreign", container); err != nil {
+		t.Fatalf("Unable to mount foreign container: %s", err)
+	}
+	if v := MustResolve[string]("foreign.config", parent.Resolver()); v != "config" {
+		t.Fatalf("Unexpected value %s", v)
+	}
+	if err := parent.Close(context.Background()); err != nil {
+		t.Fatalf("Unable to close container mounting foreign container: %s", err)
+	}
+}
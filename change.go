@@ -0,0 +1,184 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// ChangeKind describes, how a bound dependency of a Container changed
+// at runtime.
+type ChangeKind int
+
+const (
+	// ChangeSwapped signals, that the binder of a dependency was replaced
+	// by Swapper.Swap.
+	ChangeSwapped ChangeKind = iota
+	// ChangeReset signals, that the instance of a singleton dependency was
+	// discarded by Swapper.ResetSingleton or Closer.Restart.
+	ChangeReset
+	// ChangeReloaded signals, that the instance of a singleton dependency
+	// was replaced by Swapper.Reload.
+	ChangeReloaded
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeSwapped:
+		return "swapped"
+	case ChangeReset:
+		return "reset"
+	case ChangeReloaded:
+		return "reloaded"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// ChangeEvent is published to all OnChange listeners of a Container,
+// whenever a bound dependency changed at runtime. Dependents of the named
+// dependency may use it to re-resolve their dependencies.
+type ChangeEvent struct {
+	Name string
+	Kind ChangeKind
+}
+
+func (d *defaultContainer) Swap(name string, binder BinderFunc) error {
+	d.mu.Lock()
+	if d.frozen {
+		d.mu.Unlock()
+		return fmt.Errorf("unable to swap %s service: %w", name, ErrFrozen)
+	}
+	b, ok := d.table.service(name)
+	if !ok {
+		d.mu.Unlock()
+		return &notFoundError{name: name}
+	}
+	f := binderFactory(binder)
+	if b.poolSize > 0 {
+		f = poolFactory(name, b.poolSize, binder)
+	}
+	d.store(name, b.derive(f))
+	d.mu.Unlock()
+	d.notifyChange(ChangeEvent{Name: name, Kind: ChangeSwapped})
+	return d.teardown(name, b)
+}
+
+func (d *defaultContainer) ResetSingleton(name string) error {
+	d.mu.Lock()
+	if d.frozen {
+		d.mu.Unlock()
+		return fmt.Errorf("unable to reset %s service: %w", name, ErrFrozen)
+	}
+	b, ok := d.table.service(name)
+	if !ok {
+		d.mu.Unlock()
+		return &notFoundError{name: name}
+	}
+	if !b.singleton {
+		d.mu.Unlock()
+		return errors.New(fmt.Sprintf("%s service is not bound as a singleton", name))
+	}
+	d.store(name, b.derive(b.factory))
+	d.mu.Unlock()
+	d.notifyChange(ChangeEvent{Name: name, Kind: ChangeReset})
+	return d.teardown(name, b)
+}
+
+func (d *defaultContainer) Reload(name string) error {
+	d.mu.RLock()
+	frozen := d.frozen
+	b, ok := d.table.service(name)
+	d.mu.RUnlock()
+	if frozen {
+		return fmt.Errorf("unable to reload %s service: %w", name, ErrFrozen)
+	}
+	if !ok {
+		return &notFoundError{name: name}
+	}
+	if !b.singleton || b.scoped {
+		return errors.New(fmt.Sprintf("%s service is not bound as a singleton", name))
+	}
+
+	next := b.derive(b.factory)
+	ctx := context.Background()
+	start := time.Now()
+	if err := d.resolveDependencies(ctx, name, next); err != nil {
+		return fmt.Errorf("unable to reload %s service: %w", name, err)
+	}
+	value, err := d.build(ctx, name, next)
+	if err != nil {
+		return fmt.Errorf("unable to reload %s service: %w", name, err)
+	}
+	d.statsOf(name).constructed(time.Since(start))
+
+	d.mu.Lock()
+	if current, ok := d.table.service(name); !ok || current != b {
+		d.mu.Unlock()
+		if closer, ok := value.(io.Closer); ok {
+			_ = closer.Close()
+			d.releaseInstance(closer)
+		}
+		return errors.New(fmt.Sprintf("%s service changed during reload", name))
+	}
+	inst := d.instanceOf(next)
+	inst.value = value
+	inst.built.Store(true)
+	d.store(name, next)
+	d.mu.Unlock()
+	d.notifyChange(ChangeEvent{Name: name, Kind: ChangeReloaded})
+	return d.teardown(name, b)
+}
+
+func (d *defaultContainer) Restart(ctx context.Context) error {
+	d.mu.RLock()
+	frozen := d.frozen
+	d.mu.RUnlock()
+	if frozen {
+		return fmt.Errorf("unable to restart service container: %w", ErrFrozen)
+	}
+	names, err := d.closeSingletons(ctx)
+	for _, name := range names {
+		d.notifyChange(ChangeEvent{Name: name, Kind: ChangeReset})
+	}
+	d.draining.Store(false)
+	return err
+}
+
+func (d *defaultContainer) OnChange(listener func(event ChangeEvent)) error {
+	return d.addHook("change listener", func(h *hooks) {
+		h.change = append(slices.Clip(h.change), listener)
+	})
+}
+
+func (d *defaultContainer) notifyChange(event ChangeEvent) {
+	for _, listener := range d.currentHooks().change {
+		listener(event)
+	}
+}
+
+// teardown closes the singleton instance of a replaced binding, if it
+// was already constructed and implements io.Closer. Instances shared
+// with cloned containers are left open.
+func (d *defaultContainer) teardown(name string, b *binding) error {
+	d.demote(name)
+	if !b.singleton || b.shared {
+		return nil
+	}
+	inst, ok := d.instances.LoadAndDelete(b)
+	if !ok || !inst.(*instance).built.Load() {
+		return nil
+	}
+	closer, ok := inst.(*instance).value.(io.Closer)
+	if !ok {
+		return nil
+	}
+	err := closer.Close()
+	d.releaseInstance(closer)
+	if err != nil {
+		return fmt.Errorf("unable to close replaced %s service: %w", name, err)
+	}
+	return nil
+}
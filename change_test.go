@@ -0,0 +1,202 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDefaultContainer_Swap(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("foo", func(resolver ResolverFunc) any {
+		return 1
+	})
+	container.Lock()
+	resolver := container.Resolver()
+	if v := MustResolve[int]("foo", resolver); v != 1 {
+		t.Fatalf("Dependency %s has unexpected value. Expected %d got %d", "foo", 1, v)
+	}
+
+	var events []ChangeEvent
+	container.OnChange(func(event ChangeEvent) {
+		events = append(events, event)
+	})
+	err := container.Swap("foo", func(resolver ResolverFunc) any {
+		return 2
+	})
+	if err != nil {
+		t.Fatalf("Unable to swap dependency %s on locked container: %s", "foo", err)
+	}
+	if v := MustResolve[int]("foo", resolver); v != 2 {
+		t.Fatalf("Swapped dependency %s has unexpected value. Expected %d got %d", "foo", 2, v)
+	}
+	if len(events) != 1 || events[0] != (ChangeEvent{Name: "foo", Kind: ChangeSwapped}) {
+		t.Fatalf("Unexpected change events %v", events)
+	}
+
+	err = container.Swap("bar", func(resolver ResolverFunc) any {
+		return 3
+	})
+	if err == nil {
+		t.Fatalf("Swapped non existing dependency %s", "bar")
+	}
+}
+
+func TestDefaultContainer_ResetSingleton(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var num = 0
+	container.MustBindSingleton("counter", func(resolver ResolverFunc) any {
+		num++
+		return num
+	})
+	container.MustBind("instanced", func(resolver ResolverFunc) any {
+		return true
+	})
+	var events []ChangeEvent
+	container.OnChange(func(event ChangeEvent) {
+		events = append(events, event)
+	})
+
+	resolver := container.Resolver()
+	MustResolve[int]("counter", resolver)
+	if err := container.ResetSingleton("counter"); err != nil {
+		t.Fatalf("Unable to reset singleton %s: %s", "counter", err)
+	}
+	if v := MustResolve[int]("counter", resolver); v != 2 {
+		t.Fatalf("Singleton %s not constructed again. Expected %d got %d", "counter", 2, v)
+	}
+	if len(events) != 1 || events[0] != (ChangeEvent{Name: "counter", Kind: ChangeReset}) {
+		t.Fatalf("Unexpected change events %v", events)
+	}
+
+	if err := container.ResetSingleton("instanced"); err == nil {
+		t.Fatalf("Reset instanced dependency %s", "instanced")
+	}
+	if err := container.ResetSingleton("missing"); err == nil {
+		t.Fatalf("Reset non existing dependency %s", "missing")
+	}
+}
+
+type closeRecorder struct {
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestDefaultContainer_Swap_Teardown(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("closer", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	old := MustResolve[*closeRecorder]("closer", container.Resolver())
+	err := container.Swap("closer", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	if err != nil {
+		t.Fatalf("Unable to swap dependency %s: %s", "closer", err)
+	}
+	if !old.closed {
+		t.Fatalf("Replaced singleton of %s not closed", "closer")
+	}
+	current := MustResolve[*closeRecorder]("closer", container.Resolver())
+	if current == old || current.closed {
+		t.Fatalf("Swapped dependency %s still yields the replaced instance", "closer")
+	}
+}
+
+func TestDefaultContainer_Restart(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var constructed int
+	container.MustBindSingleton("closer", func(resolver ResolverFunc) any {
+		constructed++
+		return &closeRecorder{}
+	})
+	container.MustBindSingleton("unused", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	container.Lock()
+	var events []ChangeEvent
+	container.OnChange(func(event ChangeEvent) {
+		events = append(events, event)
+	})
+	old := MustResolve[*closeRecorder]("closer", container.Resolver())
+	container.Drain()
+
+	if err := container.Restart(context.Background()); err != nil {
+		t.Fatalf("Unable to restart container: %s", err)
+	}
+	if !old.closed {
+		t.Fatalf("Singleton %s not closed on restart", "closer")
+	}
+	if current := MustResolve[*closeRecorder]("closer", container.Resolver()); current == old || constructed != 2 {
+		t.Fatalf("Singleton %s not constructed again after restart", "closer")
+	}
+	if len(events) != 1 || events[0] != (ChangeEvent{Name: "closer", Kind: ChangeReset}) {
+		t.Fatalf("Unexpected change events %v", events)
+	}
+
+	container.Freeze()
+	if err := container.Restart(context.Background()); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("Restarted frozen container: %v", err)
+	}
+}
+
+func TestDefaultContainer_Reload(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var constructed int
+	container.MustBindSingleton("closer", func(resolver ResolverFunc) any {
+		constructed++
+		return &closeRecorder{}
+	})
+	container.MustBindSingleton("failing", func(resolver ResolverFunc) any {
+		return "stable"
+	}, DependsOn("config"))
+	container.MustBind("config", func(resolver ResolverFunc) any {
+		return "config"
+	})
+	container.OnResolved(func(name string, value any) error {
+		if name == "config" && constructed > 1 {
+			return errors.New("invalid config")
+		}
+		return nil
+	})
+	container.MustBind("instanced", func(resolver ResolverFunc) any {
+		return true
+	})
+	var events []ChangeEvent
+	container.OnChange(func(event ChangeEvent) {
+		events = append(events, event)
+	})
+	resolver := container.Resolver()
+	old := MustResolve[*closeRecorder]("closer", resolver)
+	MustResolve[string]("failing", resolver)
+
+	if err := container.Reload("closer"); err != nil {
+		t.Fatalf("Unable to reload singleton %s: %s", "closer", err)
+	}
+	if !old.closed {
+		t.Fatalf("Replaced singleton %s not closed", "closer")
+	}
+	if current := MustResolve[*closeRecorder]("closer", resolver); current == old || constructed != 2 {
+		t.Fatalf("Singleton %s not replaced on reload", "closer")
+	}
+	if len(events) != 1 || events[0] != (ChangeEvent{Name: "closer", Kind: ChangeReloaded}) {
+		t.Fatalf("Unexpected change events %v", events)
+	}
+
+	if err := container.Reload("failing"); err == nil {
+		t.Fatalf("Reloaded singleton %s with failing binder", "failing")
+	}
+	if v := MustResolve[string]("failing", resolver); v != "stable" {
+		t.Fatalf("Failed reload replaced singleton %s", "failing")
+	}
+	if err := container.Reload("instanced"); err == nil {
+		t.Fatalf("Reloaded instanced dependency %s", "instanced")
+	}
+	if err := container.Reload("missing"); err == nil {
+		t.Fatalf("Reloaded non existing dependency %s", "missing")
+	}
+}
@@ -0,0 +1,73 @@
+package godi
+
+import (
+	"time"
+)
+
+// ClockName is the conventional name of the Clock of a Container.
+// NewContainer binds SystemClock as singleton under it, so binders can
+// always resolve their source of time, while tests may replace it with
+// a fake through the Replace option.
+//
+//	clock := godi.MustResolve[godi.Clock](godi.ClockName, resolver)
+const ClockName = "godi.clock"
+
+// Clock is a source of time. Services resolving their Clock under
+// ClockName, instead of calling time.Now directly, remain testable
+// through a simple override.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event of a Clock, like time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t systemTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+// bindClock binds SystemClock under ClockName. The binding bypasses the
+// validation of names, as ClockName may use a reserved prefix.
+func (d *defaultContainer) bindClock() {
+	b := newBinding(binderFactory(func(resolver ResolverFunc) any {
+		return SystemClock
+	}), true)
+	b.typ = typeOf[Clock]()
+	b.builtin = true
+	d.store(ClockName, b)
+}
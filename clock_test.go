@@ -0,0 +1,49 @@
+package godi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	container := NewContainer()
+	if clock := MustResolve[Clock](ClockName, container.Resolver()); clock != SystemClock {
+		t.Fatalf("System clock not resolved by default")
+	}
+	timer := SystemClock.NewTimer(time.Millisecond)
+	if at := <-timer.C(); at.IsZero() || timer.Stop() {
+		t.Fatalf("Timer of system clock didn't fire")
+	}
+
+	if info, err := container.(*defaultContainer).Info(ClockName); err != nil || info.Kind != KindSingleton {
+		t.Fatalf("System clock not bound: %v", err)
+	}
+	h, err := container.(*defaultContainer).Handle(ClockName)
+	if err != nil {
+		t.Fatalf("Unable to create handle of system clock: %s", err)
+	}
+	if clock, err := ResolveTyped[Clock](h); err != nil || clock != SystemClock {
+		t.Fatalf("Unable to resolve system clock through handle: %v", err)
+	}
+
+	fixed := fixedClock{SystemClock}
+	if err := container.BindSingleton(ClockName, func(resolver ResolverFunc) any {
+		return fixed
+	}); err == nil {
+		t.Fatalf("Replaced clock without Replace option")
+	}
+	container.MustBindSingleton(ClockName, func(resolver ResolverFunc) any {
+		return fixed
+	}, Replace())
+	if clock := MustResolve[Clock](ClockName, container.Resolver()); clock != fixed {
+		t.Fatalf("Bound clock not resolved")
+	}
+}
+
+type fixedClock struct {
+	Clock
+}
+
+func (fixedClock) Now() time.Time {
+	return time.Time{}
+}
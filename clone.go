@@ -0,0 +1,112 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+func (d *defaultContainer) Share(name string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to share %s service: %w", name, ErrFrozen)
+	}
+	if d.locked.Load() {
+		return errors.New("service container locked. no more services can be shared")
+	}
+	b, ok := d.table.service(name)
+	if !ok {
+		return &notFoundError{name: name}
+	}
+	if !b.singleton {
+		return errors.New(fmt.Sprintf("%s service is not bound as a singleton", name))
+	}
+	next := b.copy()
+	next.shared = true
+	next.instance = d.instanceOf(b)
+	d.replace(name, b, next)
+	return nil
+}
+
+func (d *defaultContainer) Clone() Container {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	base := d.table
+	switch {
+	case base.empty() && base.parent != nil:
+		base = base.parent
+	case base.depth >= maxTableDepth:
+		base = base.flatten()
+		d.table = newTable(base)
+	default:
+		d.table = newTable(base)
+	}
+	c := &defaultContainer{
+		logger:           d.logger,
+		traceID:          d.traceID,
+		secrets:          d.secrets,
+		secretsTTL:       d.secretsTTL,
+		initTimeout:      d.initTimeout,
+		errorFormatter:   d.errorFormatter,
+		strict:           d.strict,
+		tracking:         d.tracking,
+		diagnostics:      d.diagnostics,
+		nameValidators:   slices.Clone(d.nameValidators),
+		reservedPrefixes: slices.Clone(d.reservedPrefixes),
+		table:            newTable(base),
+		mounts:           inherit(d.mounts),
+		collisionPolicy:  d.collisionPolicy,
+		phases:           d.phases,
+	}
+	c.hooks.Store(d.hooks.Load())
+	c.prefixing.Store(d.prefixing.Load())
+	c.locked.Store(d.locked.Load())
+	c.plans.Store(d.plans.Load())
+	return c
+}
+
+func (d *defaultContainer) CopyTo(dst Container, names ...string) error {
+	target, ok := dst.(*defaultContainer)
+	if !ok {
+		return errors.New("unable to copy bindings to a foreign container")
+	}
+	d.mu.RLock()
+	if len(names) == 0 {
+		d.table.eachService(func(name string, b *binding) {
+			if !b.builtin {
+				names = append(names, name)
+			}
+		})
+		d.table.eachAlias(func(name, _ string) {
+			names = append(names, name)
+		})
+		sort.Strings(names)
+	}
+	bindings := make(map[string]*binding, len(names))
+	aliases := make(map[string]string)
+	for _, name := range names {
+		if b, ok := d.table.service(name); ok {
+			bindings[name] = b
+		} else if alias, ok := d.table.alias(name); ok {
+			aliases[name] = alias
+		} else {
+			d.mu.RUnlock()
+			return &notFoundError{name: name}
+		}
+	}
+	d.mu.RUnlock()
+	for _, name := range names {
+		var err error
+		if b, ok := bindings[name]; ok {
+			_, err = target.register(name, b.copy(), bindOptions{})
+		} else {
+			err = target.Alias(name, aliases[name])
+		}
+		if err != nil {
+			return fmt.Errorf("unable to copy %s service: %w", name, err)
+		}
+	}
+	return nil
+}
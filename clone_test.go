@@ -0,0 +1,117 @@
+package godi
+
+import (
+	"testing"
+)
+
+func TestDefaultContainer_Clone(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	container.MustBindSingleton("cache", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	if err := container.Alias("salutation", "greeting"); err != nil {
+		t.Fatalf("Unable to bind alias: %s", err)
+	}
+	container.Lock()
+	cache := MustResolve[*closeRecorder]("cache", container.Resolver())
+
+	clone := container.Clone().(*defaultContainer)
+	if MustResolve[string]("salutation", clone.Resolver()) != "hello" {
+		t.Fatalf("Alias not cloned")
+	}
+	if MustResolve[*closeRecorder]("cache", clone.Resolver()) == cache {
+		t.Fatalf("Singleton instance shared with clone")
+	}
+	if err := clone.Bind("other", nil); err == nil {
+		t.Fatalf("Clone of a locked container not locked")
+	}
+	if err := clone.Swap("greeting", func(resolver ResolverFunc) any {
+		return "hi"
+	}); err != nil {
+		t.Fatalf("Unable to swap cloned dependency: %s", err)
+	}
+	if MustResolve[string]("greeting", container.Resolver()) != "hello" {
+		t.Fatalf("Swap of clone changed original container")
+	}
+}
+
+func TestDefaultContainer_Share(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	if err := container.Share("db"); err != nil {
+		t.Fatalf("Unable to share singleton: %s", err)
+	}
+	if err := container.Share("greeting"); err == nil {
+		t.Fatalf("Shared instanced dependency")
+	}
+	if err := container.Share("missing"); err == nil {
+		t.Fatalf("Shared unknown dependency")
+	}
+
+	clone := container.Clone().(*defaultContainer)
+	db := MustResolve[*closeRecorder]("db", clone.Resolver())
+	if db != MustResolve[*closeRecorder]("db", container.Resolver()) {
+		t.Fatalf("Shared singleton constructed per clone")
+	}
+	if info, _ := clone.Info("db"); !info.Shared {
+		t.Fatalf("Shared singleton not reported as shared")
+	}
+	if err := clone.ResetSingleton("db"); err != nil {
+		t.Fatalf("Unable to reset shared singleton: %s", err)
+	}
+	if db.closed {
+		t.Fatalf("Shared instance closed by reset of a clone")
+	}
+	if MustResolve[*closeRecorder]("db", container.Resolver()) != db {
+		t.Fatalf("Reset of clone discarded instance of original container")
+	}
+}
+
+func TestDefaultContainer_CopyTo(t *testing.T) {
+	catalog := NewContainer().(*defaultContainer)
+	catalog.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	}, Tags("storage"))
+	catalog.MustBind("mailer", func(resolver ResolverFunc) any {
+		return "smtp"
+	})
+	if err := catalog.Alias("database", "db"); err != nil {
+		t.Fatalf("Unable to bind alias: %s", err)
+	}
+
+	app := NewContainer().(*defaultContainer)
+	if err := catalog.CopyTo(app, "db", "database"); err != nil {
+		t.Fatalf("Unable to copy bindings: %s", err)
+	}
+	if names := app.Names(); len(names) != 3 {
+		t.Fatalf("Unexpected copied names %v", names)
+	}
+	if tagged := app.Tagged("storage"); len(tagged) != 1 {
+		t.Fatalf("Metadata not copied")
+	}
+	if MustResolve[*closeRecorder]("database", app.Resolver()) == MustResolve[*closeRecorder]("db", catalog.Resolver()) {
+		t.Fatalf("Singleton instance shared with copy")
+	}
+	if err := catalog.CopyTo(app, "missing"); err == nil {
+		t.Fatalf("Copied unknown binding")
+	}
+	if err := catalog.CopyTo(app); err == nil {
+		t.Fatalf("Copied binding twice")
+	}
+
+	all := NewContainer().(*defaultContainer)
+	if err := catalog.CopyTo(all); err != nil {
+		t.Fatalf("Unable to copy all bindings: %s", err)
+	}
+	if len(all.Names()) != 4 {
+		t.Fatalf("Not all bindings copied %v", all.Names())
+	}
+}
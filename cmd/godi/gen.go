@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// generate writes a Go source file of the given package to w, which
+// contains a typed accessor function for every binding of the wiring
+// declaring its type.
+func generate(w io.Writer, pkg string, wiring godi.Wiring) error {
+	imports := map[string]bool{"github.com/jschaefer-io/godi": true}
+	var body bytes.Buffer
+	accessors := make(map[string]string)
+	for _, entry := range wiring.Bindings {
+		if entry.Type == "" {
+			continue
+		}
+		accessor := accessorName(entry.Name)
+		if accessor == "" {
+			return fmt.Errorf("unable to derive accessor name for %s", entry.Name)
+		}
+		if other, ok := accessors[accessor]; ok {
+			return fmt.Errorf("accessor %s of %s collides with %s", accessor, entry.Name, other)
+		}
+		accessors[accessor] = entry.Name
+		if entry.Import != "" {
+			imports[entry.Import] = true
+		}
+		fmt.Fprintf(&body, "\n// %s resolves the %q dependency.\n", accessor, entry.Name)
+		fmt.Fprintf(&body, "func %s(resolver godi.ResolverFunc) (%s, error) {\n", accessor, entry.Type)
+		fmt.Fprintf(&body, "\treturn godi.Resolve[%s](%q, resolver)\n}\n", entry.Type, entry.Name)
+	}
+	if len(accessors) == 0 {
+		return errors.New("wiring declares no binding types")
+	}
+
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by godi gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	for _, path := range paths {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n%s", body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format generated code: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// accessorName derives an exported Go identifier from a binding name,
+// like UserService from "user-service".
+func accessorName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteRune('N')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
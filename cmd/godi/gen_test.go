@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+func TestGenerate(t *testing.T) {
+	wiring, err := godi.LoadWiring(strings.NewReader(`{"bindings": [
+		{"name": "user-service", "factory": "users", "type": "*user.Service", "import": "example.com/app/user"},
+		{"name": "config.http.port", "factory": "port", "type": "int"},
+		{"name": "untyped", "factory": "untyped"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unable to load wiring: %s", err)
+	}
+	var out bytes.Buffer
+	if err = generate(&out, "deps", wiring); err != nil {
+		t.Fatalf("Unable to generate accessors: %s", err)
+	}
+	code := out.String()
+	for _, expected := range []string{
+		"// Code generated by godi gen. DO NOT EDIT.",
+		"package deps",
+		`"example.com/app/user"`,
+		"func UserService(resolver godi.ResolverFunc) (*user.Service, error) {",
+		`return godi.Resolve[*user.Service]("user-service", resolver)`,
+		"func ConfigHttpPort(resolver godi.ResolverFunc) (int, error) {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("Generated code misses %q:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(code, "Untyped") {
+		t.Fatalf("Generated accessor for untyped binding:\n%s", code)
+	}
+
+	colliding := godi.Wiring{Bindings: []godi.WiringBinding{
+		{Name: "a-b", Type: "int"},
+		{Name: "a.b", Type: "int"},
+	}}
+	if err = generate(&out, "deps", colliding); err == nil {
+		t.Fatalf("Generated colliding accessors")
+	}
+}
+
+func TestAccessorName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"user-service":     "UserService",
+		"config.http.port": "ConfigHttpPort",
+		"codec@v2":         "CodecV2",
+		"2fa":              "N2fa",
+	} {
+		if actual := accessorName(name); actual != expected {
+			t.Fatalf("Unexpected accessor name for %s. Got %s expected %s", name, actual, expected)
+		}
+	}
+}
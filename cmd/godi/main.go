@@ -0,0 +1,227 @@
+// Command godi inspects the wiring of a godi.Container. It reads the
+// JSON encoded godi.Description of a container, either from a file or
+// from a debug endpoint serving it, and prints the bound dependencies,
+// renders them as a graph or compares two descriptions. For containers in
+// diagnostic mode, it prints the alive instances and where they came from.
+//
+// Usage:
+//
+//	godi list <description>
+//	godi graph <description>
+//	godi instances <description>
+//	godi diff <old-description> <new-description>
+//	godi gen [-pkg name] [-o file] <wiring>
+//
+// Descriptions are given as file paths or http(s) URLs. The graph is
+// rendered in the DOT language of Graphviz. Aliases are drawn dashed,
+// declared dependencies solid and recorded dependencies dotted. The diff
+// command exits with status 1, if the descriptions differ.
+//
+// The gen command reads a godi.Wiring file and generates a typed accessor
+// function for every binding declaring its type, replacing stringly-typed
+// Resolve calls. It is meant to be used with go generate:
+//
+//	//go:generate godi gen -pkg deps -o accessors_gen.go wiring.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: godi list|graph|instances <description>")
+		fmt.Fprintln(flag.CommandLine.Output(), "       godi diff <old-description> <new-description>")
+		fmt.Fprintln(flag.CommandLine.Output(), "       godi gen [-pkg name] [-o file] <wiring>")
+	}
+	flag.Parse()
+	code, err := run(os.Stdout, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "godi:", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	os.Exit(code)
+}
+
+func run(w io.Writer, args []string) (int, error) {
+	if len(args) < 2 {
+		return 0, errors.New("missing command or description")
+	}
+	switch args[0] {
+	case "gen":
+		return 0, runGen(w, args[1:])
+	case "list", "graph", "instances":
+		description, err := load(args[1])
+		if err != nil {
+			return 0, err
+		}
+		switch args[0] {
+		case "list":
+			printList(w, description)
+		case "graph":
+			printGraph(w, description)
+		default:
+			printInstances(w, description)
+		}
+		return 0, nil
+	case "diff":
+		if len(args) != 3 {
+			return 0, errors.New("diff requires two descriptions")
+		}
+		old, err := load(args[1])
+		if err != nil {
+			return 0, err
+		}
+		next, err := load(args[2])
+		if err != nil {
+			return 0, err
+		}
+		if printDiff(w, old, next) {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("unknown command %s", args[0])
+}
+
+func runGen(w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	pkg := flags.String("pkg", "deps", "package name of the generated file")
+	output := flags.String("o", "", "output file, defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("gen requires a wiring file")
+	}
+	wiring, err := godi.LoadWiringFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		return generate(w, *pkg, wiring)
+	}
+	var buf bytes.Buffer
+	if err = generate(&buf, *pkg, wiring); err != nil {
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
+
+// load reads a description from a file or an http(s) URL.
+func load(source string) (godi.Description, error) {
+	var r io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		res, err := http.Get(source)
+		if err != nil {
+			return godi.Description{}, err
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return godi.Description{}, fmt.Errorf("unable to fetch %s: %s", source, res.Status)
+		}
+		r = res.Body
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return godi.Description{}, err
+		}
+		r = file
+	}
+	defer r.Close()
+	var description godi.Description
+	if err := json.NewDecoder(r).Decode(&description); err != nil {
+		return godi.Description{}, fmt.Errorf("unable to decode description %s: %w", source, err)
+	}
+	return description, nil
+}
+
+func printList(w io.Writer, description godi.Description) {
+	for _, info := range description.Bindings {
+		line := fmt.Sprintf("%s\t%s", info.Name, info.Kind)
+		if info.Target != "" {
+			line += " -> " + info.Target
+		}
+		if info.Deprecated {
+			line += "\tdeprecated"
+			if info.Replacement != "" {
+				line += ", use " + info.Replacement
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// printInstances prints the alive instances of the description with their
+// creation time and the chain of dependencies, which resolved them.
+func printInstances(w io.Writer, description godi.Description) {
+	for _, info := range description.Instances {
+		line := fmt.Sprintf("#%d\t%s\t%s", info.ID, info.Name, info.Created.Format(time.RFC3339))
+		if len(info.Chain) > 0 {
+			line += "\tvia " + strings.Join(info.Chain, " -> ")
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func printGraph(w io.Writer, description godi.Description) {
+	fmt.Fprintln(w, "digraph godi {")
+	for _, info := range description.Bindings {
+		fmt.Fprintf(w, "\t%q [label=%q];\n", info.Name, fmt.Sprintf("%s\n%s", info.Name, info.Kind))
+	}
+	for _, info := range description.Bindings {
+		if info.Target != "" {
+			fmt.Fprintf(w, "\t%q -> %q [style=dashed];\n", info.Name, info.Target)
+		}
+		for _, dependency := range info.DependsOn {
+			fmt.Fprintf(w, "\t%q -> %q;\n", info.Name, dependency)
+		}
+		for _, dependency := range info.Resolves {
+			if !slices.Contains(info.DependsOn, dependency) {
+				fmt.Fprintf(w, "\t%q -> %q [style=dotted];\n", info.Name, dependency)
+			}
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// printDiff prints all bindings added, changed or removed between the
+// old and the next description, as compared by godi.DiffDescriptions, and
+// reports, whether any differences exist.
+func printDiff(w io.Writer, old, next godi.Description) bool {
+	report := godi.DiffDescriptions(old, next)
+	for _, info := range report.Added {
+		fmt.Fprintf(w, "+ %s\t%s\n", info.Name, info.Kind)
+	}
+	for _, change := range report.Changed {
+		fmt.Fprintf(w, "~ %s\t%s -> %s\t%s\n", change.Name, describe(change.Before), describe(change.After), strings.Join(change.Fields, ","))
+	}
+	for _, info := range report.Removed {
+		fmt.Fprintf(w, "- %s\t%s\n", info.Name, info.Kind)
+	}
+	return !report.Empty()
+}
+
+func describe(info godi.BindingInfo) string {
+	text := info.Kind.String()
+	if info.Target != "" {
+		text += "(" + info.Target + ")"
+	}
+	if info.Deprecated {
+		text += ",deprecated"
+	}
+	return text
+}
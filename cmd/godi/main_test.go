@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+func writeDescription(t *testing.T, c godi.Container) string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Unable to encode description: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "description.json")
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Unable to write description: %s", err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	handler := func(resolver godi.ResolverFunc) any {
+		return true
+	}
+	old := godi.NewContainer()
+	old.MustBind("foo", handler)
+	old.MustBind("bar", handler)
+	next := godi.NewContainer()
+	next.MustBindSingleton("foo", handler)
+	next.MustBind("baz", handler, godi.DependsOn("foo"))
+	if err := next.Alias("qux", "baz"); err != nil {
+		t.Fatalf("Unable to alias dependency %s: %s", "baz", err)
+	}
+	oldPath := writeDescription(t, old)
+	nextPath := writeDescription(t, next)
+
+	var out bytes.Buffer
+	if _, err := run(&out, []string{"list", nextPath}); err != nil {
+		t.Fatalf("Unable to list description: %s", err)
+	}
+	if expected := "baz\tinstanced\nfoo\tsingleton\ngodi.clock\tsingleton\nqux\talias -> baz\n"; out.String() != expected {
+		t.Fatalf("Unexpected list output %q, expected %q", out.String(), expected)
+	}
+
+	out.Reset()
+	if _, err := run(&out, []string{"graph", nextPath}); err != nil {
+		t.Fatalf("Unable to render graph: %s", err)
+	}
+	if !strings.Contains(out.String(), `"qux" -> "baz"`) {
+		t.Fatalf("Graph misses alias edge: %s", out.String())
+	}
+	if !strings.Contains(out.String(), `"baz" -> "foo";`) {
+		t.Fatalf("Graph misses dependency edge: %s", out.String())
+	}
+
+	out.Reset()
+	code, err := run(&out, []string{"diff", oldPath, nextPath})
+	if err != nil {
+		t.Fatalf("Unable to diff descriptions: %s", err)
+	}
+	expected := "+ baz\tinstanced\n+ qux\talias\n~ foo\tinstanced -> singleton\tkind,location\n- bar\tinstanced\n"
+	if code != 1 || out.String() != expected {
+		t.Fatalf("Unexpected diff output %q with code %d, expected %q", out.String(), code, expected)
+	}
+
+	out.Reset()
+	if code, _ = run(&out, []string{"diff", oldPath, oldPath}); code != 0 || out.Len() != 0 {
+		t.Fatalf("Expected no differences, got %q", out.String())
+	}
+	tagged := old.(godi.Cloner).Clone()
+	if err = tagged.Tag("foo", "http"); err != nil {
+		t.Fatalf("Unable to tag dependency %s: %s", "foo", err)
+	}
+	out.Reset()
+	if code, _ = run(&out, []string{"diff", oldPath, writeDescription(t, tagged)}); code != 1 || out.String() != "~ foo\tinstanced -> instanced\ttags\n" {
+		t.Fatalf("Unexpected diff output %q with code %d for changed tags", out.String(), code)
+	}
+	if _, err = run(&out, []string{"explode", oldPath}); err == nil {
+		t.Fatalf("Unknown command did not fail")
+	}
+}
+
+func TestRun_Instances(t *testing.T) {
+	container := godi.NewContainer(godi.WithDiagnostics())
+	container.MustBind("connection", func(resolver godi.ResolverFunc) any {
+		return &bytes.Buffer{}
+	})
+	container.MustBindSingleton("repository", func(resolver godi.ResolverFunc) any {
+		godi.MustResolve[*bytes.Buffer]("connection", resolver)
+		return &strings.Builder{}
+	})
+	godi.MustResolve[*strings.Builder]("repository", container.Resolver())
+	path := writeDescription(t, container)
+
+	var out bytes.Buffer
+	if _, err := run(&out, []string{"instances", path}); err != nil {
+		t.Fatalf("Unable to list instances: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "#1\tconnection\t") || !strings.HasSuffix(lines[0], "\tvia repository") || !strings.HasPrefix(lines[1], "#2\trepository\t") {
+		t.Fatalf("Unexpected instances output %q", out.String())
+	}
+}
@@ -0,0 +1,75 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+)
+
+func (d *defaultContainer) BindWhen(name string, predicate func() bool, primary, fallback BinderFunc) error {
+	if predicate == nil || primary == nil || fallback == nil {
+		return errors.New(fmt.Sprintf("unable to bind %s service. predicate and binders must not be nil", name))
+	}
+	return d.Bind(name, func(resolver ResolverFunc) any {
+		if predicate() {
+			return primary(resolver)
+		}
+		return fallback(resolver)
+	})
+}
+
+// Canary tracks a dependency bound through ExtendedBinder.BindCanary. It allows
+// adjusting the percentage of requests routed to the canary implementation
+// at runtime and exposes counters of how often each implementation was used.
+type Canary struct {
+	percent atomic.Int64
+	stable  atomic.Uint64
+	canary  atomic.Uint64
+}
+
+// Percent returns the percentage of requests currently routed to the canary
+// implementation.
+func (c *Canary) Percent() int {
+	return int(c.percent.Load())
+}
+
+// SetPercent changes the percentage of requests routed to the canary
+// implementation. Values are clamped to the range of 0 to 100.
+func (c *Canary) SetPercent(percent int) {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	c.percent.Store(int64(percent))
+}
+
+// StableCount returns the number of requests served by the stable implementation.
+func (c *Canary) StableCount() uint64 {
+	return c.stable.Load()
+}
+
+// CanaryCount returns the number of requests served by the canary implementation.
+func (c *Canary) CanaryCount() uint64 {
+	return c.canary.Load()
+}
+
+func (c *Canary) route() bool {
+	if rand.Intn(100) < c.Percent() {
+		c.canary.Add(1)
+		return true
+	}
+	c.stable.Add(1)
+	return false
+}
+
+func (d *defaultContainer) BindCanary(name string, percent int, stable, canary BinderFunc) (*Canary, error) {
+	c := &Canary{}
+	c.SetPercent(percent)
+	if err := d.BindWhen(name, c.route, canary, stable); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,82 @@
+package godi
+
+import (
+	"testing"
+)
+
+func TestDefaultContainer_BindWhen(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	enabled := false
+	container.MustBindSingleton("flags", func(resolver ResolverFunc) any {
+		return &enabled
+	})
+	flags := MustResolve[*bool]("flags", container.Resolver())
+	err := container.BindWhen("greeter", func() bool {
+		return *flags
+	}, func(resolver ResolverFunc) any {
+		return "new"
+	}, func(resolver ResolverFunc) any {
+		return "old"
+	})
+	if err != nil {
+		t.Fatalf("Unable to bind conditional dependency %s: %s", "greeter", err)
+	}
+
+	if v := MustResolve[string]("greeter", container.Resolver()); v != "old" {
+		t.Fatalf("Expected fallback implementation, got %s", v)
+	}
+	enabled = true
+	if v := MustResolve[string]("greeter", container.Resolver()); v != "new" {
+		t.Fatalf("Expected primary implementation, got %s", v)
+	}
+
+	binder := func(resolver ResolverFunc) any {
+		return "binder"
+	}
+	if err := container.BindWhen("nil-predicate", nil, binder, binder); err == nil {
+		t.Fatalf("Bound conditional dependency without predicate")
+	}
+	if err := container.BindWhen("nil-fallback", func() bool {
+		return true
+	}, binder, nil); err == nil {
+		t.Fatalf("Bound conditional dependency without fallback")
+	}
+	if _, err := container.BindCanary("nil-canary", 50, binder, nil); err == nil {
+		t.Fatalf("Bound canary dependency without canary")
+	}
+}
+
+func TestDefaultContainer_BindCanary(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	canary, err := container.BindCanary("codec", 0, func(resolver ResolverFunc) any {
+		return "stable"
+	}, func(resolver ResolverFunc) any {
+		return "canary"
+	})
+	if err != nil {
+		t.Fatalf("Unable to bind canary dependency %s: %s", "codec", err)
+	}
+	resolver := container.Resolver()
+	for i := 0; i < 10; i++ {
+		if v := MustResolve[string]("codec", resolver); v != "stable" {
+			t.Fatalf("Expected stable implementation, got %s", v)
+		}
+	}
+	canary.SetPercent(100)
+	for i := 0; i < 5; i++ {
+		if v := MustResolve[string]("codec", resolver); v != "canary" {
+			t.Fatalf("Expected canary implementation, got %s", v)
+		}
+	}
+	if canary.StableCount() != 10 || canary.CanaryCount() != 5 {
+		t.Fatalf("Unexpected counters. Got stable %d canary %d", canary.StableCount(), canary.CanaryCount())
+	}
+
+	canary.SetPercent(250)
+	if canary.Percent() != 100 {
+		t.Fatalf("Percentage not clamped. Got %d", canary.Percent())
+	}
+	if _, err = container.BindCanary("codec", 50, nil, nil); err == nil {
+		t.Fatalf("Could override already existing dependency %s", "codec")
+	}
+}
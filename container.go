@@ -24,9 +24,17 @@
 package godi
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime/pprof"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ResolverFunc is a generic function, used to request a dependency from
@@ -40,6 +48,21 @@ type ResolverFunc = func(string) (any, error)
 // dependencies as needed.
 type BinderFunc = func(resolver ResolverFunc) any
 
+// ContextBinderFunc is a BinderFunc, which additionally receives the
+// context of the resolution and may fail. It is bound through BindCtx.
+type ContextBinderFunc = func(ctx context.Context, resolver ResolverFunc) (any, error)
+
+// ResolvedHookFunc is a generic function, which is executed for every
+// value produced by a binder before it is handed out by the ResolverFunc.
+// Returning an error turns the resolution of the named dependency into
+// a failed resolution.
+type ResolvedHookFunc = func(name string, value any) error
+
+// PostProcessorFunc is a generic function, which receives every value
+// produced by a binder and returns the value, which should be used instead.
+// It may return the given value as is, augment it or wrap it entirely.
+type PostProcessorFunc = func(name string, value any) any
+
 // Container is the main interface for the dependency collection container.
 // Through the Container, multiple dependencies can be prepared and stored
 // by an identifying name and resolved on demand by this name.
@@ -50,82 +73,883 @@ type BinderFunc = func(resolver ResolverFunc) any
 // BindSingleton method. Singleton dependencies are instanced once lazily,
 // when requested for the first time. All further dependency requests
 // receive this first instance. Both binding methods offer a variant, which
-// panics on a failed bind.
+// panics on a failed bind. Both accept BindOptions, which configure the
+// binding further: Eager, Scoped, Tags, DependsOn and Qualifier. Binding a
+// name twice fails, unless the binding intentionally overwrites the former
+// one through the Replace option. BindIfAbsent binds only, if the name is
+// still free, allowing libraries to provide defaults, which applications
+// pre-empt by binding first. GetOrBindSingleton atomically resolves a
+// dependency or binds and resolves it as singleton with the given
+// BindOptions, if the name is free, building registries of dependencies
+// lazily at runtime. BindCtx binds
+// an instanced dependency, whose binder receives the context passed to
+// ResolverContext, so constructors may honor its deadline and read
+// request-scoped values, and report errors instead of panicking.
+// BindPool binds a dependency, whose instances are maintained by a Pool of
+// limited size. Resolving it yields the *Pool, from which instances are
+// checked out and back in. Binders are executed with the pprof label
+// godi.service set to the name of the constructed dependency, attributing
+// construction costs in CPU and goroutine profiles to their binding.
+//
+// Conditional dependencies are bound through BindWhen. Its predicate is
+// evaluated on every request and selects, whether the primary or the
+// fallback binder constructs the dependency. This allows implementations
+// to be switched by feature flags without changing any call sites.
+// BindCanary routes a percentage of all requests to a canary implementation
+// and the rest to the stable one, de-risking the replacement of critical
+// dependencies.
+//
+// Alias makes a dependency available under an additional name. Multiple
+// versions of a dependency may be bound under one name through BindVersion.
+// Each version is resolvable by its VersionName, while the plain name
+// resolves the default version. The first bound version serves as the
+// default, unless changed with SetDefaultVersion.
+//
+// Secrets are bound through BindSecret. Resolving a secret fetches it by its
+// key from the SecretsProvider configured with WithSecretsProvider, within
+// the context of the resolution. Fetched secrets are cached for the
+// configured ttl.
 //
 // Once all Dependencies are bound to the container. You may call Lock
-// to prevent any more modification of the allowed dependencies. To resolve
+// to prevent any more modification of the allowed dependencies. Lock is
+// safe for concurrent use and calling it repeatedly has no further effect.
+// Lock compiles a resolution plan for every service from the declared
+// dependencies and the dependencies recorded so far, constructing the
+// singletons a service depends on in a flat loop, deepest first, before
+// the service itself. Locked reports, whether the Container was locked already.
+// Warmup constructs all Eager singletons within the given context, like
+// Lock does. Once the context is done, no further constructions are
+// started. Singletons assigned to a Phase are constructed first, one
+// phase after another and concurrently within each phase. Once a phase
+// failed, later phases are skipped. The WarmupReport lists the completed,
+// failed and skipped singletons. Singletons left unconstructed are
+// constructed lazily on their first request. Freeze
+// locks the Container and additionally rejects all runtime changes, like
+// Swap and ResetSingleton, with ErrFrozen. A frozen Container is fully
+// immutable and may be shared with untrusted subsystems. To resolve
 // a dependency by its name, get the ResolverFunc by calling Resolver. You
 // may use the Resolve or MustResolve helper functions to handle the type
-// conversion for you.
+// conversion for you. ResolverContext returns a ResolverFunc, which performs
+// all resolutions within the given context, including the resolutions of
+// nested dependencies requested by binders. Handle returns a pre-resolved
+// Handle for hot paths resolving the same dependency repeatedly. NewScope of
+// the Scoper capability creates a Scope, which
+// resolves dependencies of the Container, but allows additional values to be
+// provided for the duration of a unit of work. ScopeOptions configure the
+// created Scope.
+//
+// Post-processors registered through AddPostProcessor are applied in order
+// of their registration to every constructed dependency. Singleton
+// dependencies are post-processed only once, on their construction.
+// Hooks registered through OnResolved are executed in order of their
+// registration after a dependency has been constructed, allowing validation
+// of the resolved values at the source.
+//
+// Additionally, listeners may subscribe to events of the Container. OnBind
+// listeners are notified about every newly bound dependency, OnResolve
+// listeners about every successful resolution including its duration and
+// OnMiss listeners about every lookup of a dependency, which is not bound.
+// Frozen Containers reject new hooks, post-processors and listeners with
+// ErrFrozen.
+//
+// Bound dependencies may be changed at runtime, even on a locked Container.
+// Swap of the Swapper capability replaces the binder of a dependency while
+// keeping its binding type,
+// ResetSingleton discards an already constructed singleton instance, so it
+// is constructed again on the next request. Replaced singleton instances
+// implementing io.Closer are closed after the change. Reload constructs
+// a replacement for a singleton instance, while requests still resolve
+// the old one, and swaps it in only once it was constructed successfully.
+// Restart discards and closes all constructed singletons at once, like
+// Close, and ends a Drain, so long-running processes can cycle their
+// dependencies, e.g. after rotating credentials, while keeping all
+// bindings. Every change is
+// published as a ChangeEvent to all listeners registered through OnChange.
+//
+// Dependencies may be marked as deprecated through Deprecate. The first
+// request of a deprecated dependency logs a warning, naming its replacement.
+//
+// Dependencies may be tagged through Tag, allowing groups of dependencies
+// to be discovered by their tag through Tagged.
+//
+// Clone copies the Container with all of its bindings, e.g. to override
+// single dependencies per test or tenant. Bindings are shared copy-on-write
+// between the Container and its clones, so cloning is cheap regardless of
+// the number of bindings. Singletons are constructed anew by each clone,
+// unless they are marked through Share. Shared singletons are constructed
+// once and used by the Container and all of its clones. CopyTo copies
+// single bindings including their metadata to another Container, e.g. to
+// assemble sub-applications from a catalog of bindings. Without names, it
+// copies all bindings except the ones every Container provides, like the
+// default Clock bound under ClockName. Apply binds
+// a ProviderSet, a reusable bundle of bindings declared by a library,
+// after checking none of its names conflict. Install binds Modules,
+// named ProviderSets whose private bindings are only resolvable by the
+// binders of the same Module. Installing fails without binding anything,
+// if a Module requires a service, which is neither bound already nor
+// provided by one of the installed Modules. Names bound by more than one
+// Module are rejected, unless the CollisionPolicy configured through
+// WithCollisionPolicy prefixes private names. Mount makes all dependencies
+// of another Container, e.g. of a sub-application, resolvable as
+// "<prefix>.<name>". Names bound to the Container itself take precedence
+// over mounted ones. Containers mounting the Container can't be mounted.
+// Closing the Container closes all Containers it mounted, while clones
+// leave the mounts they inherited open.
+//
+// The Introspector capability inspects the Container. Names lists all
+// names bound to the Container, including aliases. Info
+// describes how a name is bound to the Container, including whether
+// the instance of a singleton was constructed already. Stats reports
+// resolution statistics for every bound dependency. In diagnostic mode,
+// Instances lists all alive instances and InstanceOf reports, which
+// resolution constructed a given instance. Explain resolves a
+// dependency while recording all nested resolutions as a Tree, including
+// their timings and whether cached instances were used. The Container
+// encodes to JSON as its Description, including the kind, tags, declared
+// dependencies and bind location of every binding.
+//
+// The Container records, which dependencies every binder actually
+// resolves, building the dependency graph without explicit DependsOn
+// declarations. The recorded dependencies are reported by Info. Close of
+// the Closer capability closes all constructed singletons implementing
+// io.Closer in reverse order of their dependencies, so no dependency is
+// closed before the dependencies using it. If the context of Close is done before all
+// singletons were closed, a ShutdownError names the services, which
+// are blocking the shutdown. Once closed, the Container no longer
+// references the singletons, so they can be garbage collected, even
+// while the Container itself is still referenced.
+//
+// Drain prepares the shutdown. Resolutions in flight complete, but new
+// resolutions of instanced and scoped dependencies fail with ErrDraining,
+// so nothing new acquires resources while requests finish.
+//
+// Only binding and resolving are part of the Container interface. The
+// other methods described above are provided by capabilities: Drain,
+// Close and Restart by Closer, Warmup by Warmer, Freeze by Freezer, Clone
+// by Cloner, CopyTo, Apply, Mount and Install by Composer, Deprecate and
+// Share by Annotator, Handle by Handler, BindPool, BindSecret and
+// BindCanary by ExtendedBinder, the inspection methods by Introspector,
+// the runtime changes by Swapper and NewScope by Scoper. The Container
+// created by NewContainer implements all capabilities. Other
+// implementations of Container may lack them, so callers discover them
+// through the As helpers, like AsCloser.
 type Container interface {
 	Lock()
-	Bind(name string, binder BinderFunc) error
-	MustBind(name string, binder BinderFunc)
-	BindSingleton(name string, binder BinderFunc) error
-	MustBindSingleton(name string, binder BinderFunc)
+	Locked() bool
+	Bind(name string, binder BinderFunc, options ...BindOption) error
+	MustBind(name string, binder BinderFunc, options ...BindOption)
+	BindCtx(name string, binder ContextBinderFunc, options ...BindOption) error
+	BindSingleton(name string, binder BinderFunc, options ...BindOption) error
+	BindIfAbsent(name string, binder BinderFunc, options ...BindOption) (bool, error)
+	GetOrBindSingleton(name string, binder BinderFunc, options ...BindOption) (any, error)
+	MustBindSingleton(name string, binder BinderFunc, options ...BindOption)
+	BindWhen(name string, predicate func() bool, primary, fallback BinderFunc) error
+	BindVersion(name, version string, binder BinderFunc) error
+	SetDefaultVersion(name, version string) error
+	Alias(name, target string) error
+	OnResolved(hook ResolvedHookFunc) error
+	AddPostProcessor(processor PostProcessorFunc) error
+	OnBind(listener func(name string)) error
+	OnResolve(listener func(name string, duration time.Duration)) error
+	OnMiss(listener func(name string)) error
+	Tag(name string, tags ...string) error
+	Tagged(tag string) []string
+	OnChange(listener func(event ChangeEvent)) error
 	Resolver() ResolverFunc
+	ResolverContext(ctx context.Context) ResolverFunc
+}
+
+// ContainerOption configures a Container created by NewContainer.
+type ContainerOption func(d *defaultContainer)
+
+// WithLogger sets the logger, used by the Container to report noteworthy
+// events like the usage of deprecated dependencies. By default, the
+// Container uses slog.Default.
+func WithLogger(logger *slog.Logger) ContainerOption {
+	return func(d *defaultContainer) {
+		d.logger = logger
+	}
+}
+
+// WithTraceID sets a function extracting a trace or request ID from the
+// context of a resolution started through Container.ResolverContext.
+// The extracted ID is included in the debug logs of the Container, so
+// resolution activity can be tied back to its originating request.
+func WithTraceID(extract func(ctx context.Context) string) ContainerOption {
+	return func(d *defaultContainer) {
+		d.traceID = extract
+	}
 }
 
 // NewContainer instantiates a generic Container, which can be filled
 // with instanced or singleton dependencies, locked and queried for
 // dependencies.
-func NewContainer() Container {
+func NewContainer(options ...ContainerOption) Container {
 	s := defaultContainer{
-		locked:   false,
-		services: make(map[string]BinderFunc),
+		table: newTable(nil),
 	}
+	for _, option := range options {
+		option(&s)
+	}
+	s.bindClock()
 	return &s
 }
 
+// factory constructs the value of a binding within the context
+// of its resolution.
+type factory = func(ctx context.Context, resolver ResolverFunc) (any, error)
+
+// binderFactory adapts a BinderFunc to a factory. A nil binder yields
+// a nil factory, which strict Containers reject.
+func binderFactory(binder BinderFunc) factory {
+	if binder == nil {
+		return nil
+	}
+	return func(_ context.Context, resolver ResolverFunc) (any, error) {
+		return binder(resolver), nil
+	}
+}
+
+// binding describes how a dependency is constructed. Bindings are never
+// modified once bound, as they may be shared with clones of the Container.
+// Changes replace the binding by a modified copy instead.
+type binding struct {
+	factory     factory
+	singleton   bool
+	poolSize    int
+	shared      bool
+	scoped      bool
+	eager       bool
+	qualifier   string
+	dependsOn   []string
+	typ         reflect.Type
+	primary     bool
+	location    string
+	initTimeout time.Duration
+	deprecation *deprecation
+	tags        []string
+	// module is the name of the Module the binding was installed by.
+	// Private bindings are only visible to binders of the same module.
+	module   string
+	private  bool
+	priority int
+	phase    string
+	// builtin marks the bindings every Container created by NewContainer
+	// provides, like the SystemClock.
+	builtin bool
+	// instance holds the instance of a shared singleton, which is used
+	// by the Container and all of its clones.
+	instance *instance
+}
+
+// instance holds the constructed value of a singleton binding.
+type instance struct {
+	mu    sync.Mutex
+	built atomic.Bool
+	value any
+}
+
+func newBinding(f factory, singleton bool) *binding {
+	return &binding{factory: f, singleton: singleton}
+}
+
+// copy returns a modifiable copy of b.
+func (b *binding) copy() *binding {
+	next := *b
+	return &next
+}
+
+// derive creates a fresh binding for the given factory, which keeps all
+// metadata of b but none of its constructed state.
+func (b *binding) derive(f factory) *binding {
+	next := b.copy()
+	next.factory = f
+	next.builtin = false
+	if next.shared {
+		next.instance = &instance{}
+	}
+	return next
+}
+
 type defaultContainer struct {
-	locked   bool
-	services map[string]BinderFunc
+	mu               sync.RWMutex
+	logger           *slog.Logger
+	traceID          func(ctx context.Context) string
+	secrets          SecretsProvider
+	secretsTTL       time.Duration
+	locked           atomic.Bool
+	draining         atomic.Bool
+	frozen           bool
+	strict           bool
+	nameValidators   []func(name string) error
+	reservedPrefixes []string
+	table            *table
+	instances        sync.Map // *binding -> *instance
+	stats            sync.Map // string -> *bindingStats
+	tracking         bool
+	diagnostics      bool
+	instanceIDs      atomic.Uint64
+	tracked          sync.Map // uintptr -> *trackedInstance
+	edges            sync.Map // edge -> struct{}
+	plans            atomic.Pointer[plans]
+	hot              sync.Map // string -> *hotSingleton
+	generation       atomic.Uint64
+	initTimeout      time.Duration
+	errorFormatter   ErrorFormatterFunc
+	hooks            atomic.Pointer[hooks]
+	mounts           map[string]mount
+	collisionPolicy  CollisionPolicy
+	phases           []string
+	prefixing        atomic.Bool
 }
 
 func (d *defaultContainer) Lock() {
-	d.locked = true
+	d.mu.Lock()
+	if d.locked.Swap(true) {
+		d.mu.Unlock()
+		return
+	}
+	compiled := d.compilePlans()
+	d.plans.Store(&compiled)
+	d.mu.Unlock()
+	phases := d.eagerPhases()
+	report, _ := d.warmup(context.Background(), phases)
+	for _, phase := range phases {
+		for _, name := range phase.names {
+			if err, ok := report.Failed[name]; ok {
+				d.log().Error("eager service construction failed", slog.String("service", name), slog.Any("error", err))
+			}
+		}
+	}
 }
 
-func (d *defaultContainer) Bind(name string, binder BinderFunc) error {
-	if d.locked {
-		return errors.New("service container locked. no more services can be bound")
+func (d *defaultContainer) Locked() bool {
+	return d.locked.Load()
+}
+
+func (d *defaultContainer) Bind(name string, binder BinderFunc, options ...BindOption) error {
+	return d.bind(name, newBinding(binderFactory(binder), false), options...)
+}
+
+func (d *defaultContainer) BindCtx(name string, binder ContextBinderFunc, options ...BindOption) error {
+	return d.bind(name, newBinding(binder, false), options...)
+}
+
+func (d *defaultContainer) bind(name string, b *binding, options ...BindOption) error {
+	_, err := d.bindWith(name, b, newBindOptions(options))
+	return err
+}
+
+// bindWith configures the new binding b according to the options and
+// registers it.
+func (d *defaultContainer) bindWith(name string, b *binding, o bindOptions) (bool, error) {
+	if err := o.apply(b); err != nil {
+		return false, errors.New(fmt.Sprintf("unable to bind %s service: %s", name, err))
 	}
-	if _, ok := d.services[name]; ok {
-		return errors.New(fmt.Sprintf("service with name %s already bound", name))
+	if o.qualifier != "" {
+		name = QualifiedName(name, o.qualifier)
 	}
-	d.services[name] = binder
-	return nil
+	b.location = bindLocation()
+	return d.register(name, b, o)
 }
 
-func (d *defaultContainer) MustBind(name string, binder BinderFunc) {
-	if err := d.Bind(name, binder); err != nil {
-		panic(err.Error())
+// register binds the configured binding b under the given name and
+// reports, whether b was bound.
+func (d *defaultContainer) register(name string, b *binding, o bindOptions) (bool, error) {
+	d.mu.Lock()
+	if d.frozen {
+		d.mu.Unlock()
+		return false, fmt.Errorf("unable to bind %s service: %w", name, ErrFrozen)
+	}
+	if d.locked.Load() {
+		d.mu.Unlock()
+		return false, errors.New("service container locked. no more services can be bound")
+	}
+	if err := d.validate(name, b); err != nil {
+		d.mu.Unlock()
+		return false, err
+	}
+	_, aliased := d.table.alias(name)
+	old, ok := d.table.service(name)
+	if o.ifAbsent && (aliased || ok) {
+		d.mu.Unlock()
+		return false, nil
+	}
+	if aliased {
+		d.mu.Unlock()
+		return false, errors.New(fmt.Sprintf("service with name %s already bound as alias %s", name, strings.Join(d.aliasChain(name), " -> ")))
+	}
+	if ok && !o.replace {
+		d.mu.Unlock()
+		return false, errors.New(fmt.Sprintf("service with name %s already bound", name))
 	}
+	d.store(name, b)
+	d.mu.Unlock()
+	for _, listener := range d.currentHooks().bind {
+		listener(name)
+	}
+	if ok {
+		return true, d.teardown(name, old)
+	}
+	return true, nil
+}
+
+func (d *defaultContainer) BindIfAbsent(name string, binder BinderFunc, options ...BindOption) (bool, error) {
+	o := newBindOptions(options)
+	o.ifAbsent = true
+	return d.bindWith(name, newBinding(binderFactory(binder), false), o)
+}
+
+func (d *defaultContainer) GetOrBindSingleton(name string, binder BinderFunc, options ...BindOption) (any, error) {
+	o := newBindOptions(options)
+	o.ifAbsent = true
+	bound := name
+	if o.qualifier != "" {
+		bound = QualifiedName(name, o.qualifier)
+	}
+	d.mu.RLock()
+	_, ok := d.table.service(bound)
+	if !ok {
+		_, ok = d.table.alias(bound)
+	}
+	d.mu.RUnlock()
+	if !ok {
+		if _, err := d.bindWith(name, newBinding(binderFactory(binder), true), o); err != nil {
+			return nil, err
+		}
+	}
+	return d.resolve(context.Background(), bound)
 }
 
-func (d *defaultContainer) BindSingleton(name string, binder BinderFunc) error {
-	var lazyBind sync.Once
-	var result any
-	bind := func(resolver ResolverFunc) any {
-		lazyBind.Do(func() {
-			result = binder(resolver)
-		})
-		return result
+// replace records the modified copy next of the binding b, keeping the
+// constructed state of b. The caller must hold the mutex of the Container.
+func (d *defaultContainer) replace(name string, b, next *binding) {
+	if inst, ok := d.instances.LoadAndDelete(b); ok && !next.shared {
+		d.instances.Store(next, inst)
 	}
-	return d.Bind(name, bind)
+	d.store(name, next)
 }
 
-func (d *defaultContainer) MustBindSingleton(name string, binder BinderFunc) {
-	if err := d.BindSingleton(name, binder); err != nil {
+func (d *defaultContainer) MustBind(name string, binder BinderFunc, options ...BindOption) {
+	if err := d.Bind(name, binder, options...); err != nil {
+		ReportPanic(name, err)
 		panic(err.Error())
 	}
 }
 
+func (d *defaultContainer) BindSingleton(name string, binder BinderFunc, options ...BindOption) error {
+	return d.bind(name, newBinding(binderFactory(binder), true), options...)
+}
+
+func (d *defaultContainer) MustBindSingleton(name string, binder BinderFunc, options ...BindOption) {
+	if err := d.BindSingleton(name, binder, options...); err != nil {
+		ReportPanic(name, err)
+		panic(err.Error())
+	}
+}
+
+// hooks holds the hooks and listeners of a Container. It is never
+// modified, but replaced as a whole, once a hook is added, so resolutions
+// read it without holding the mutex of the Container.
+type hooks struct {
+	resolved       []ResolvedHookFunc
+	postProcessors []PostProcessorFunc
+	bind           []func(name string)
+	resolve        []func(name string, duration time.Duration)
+	miss           []func(name string)
+	change         []func(event ChangeEvent)
+}
+
+var noHooks = &hooks{}
+
+// currentHooks returns the hooks and listeners of the Container.
+func (d *defaultContainer) currentHooks() *hooks {
+	if h := d.hooks.Load(); h != nil {
+		return h
+	}
+	return noHooks
+}
+
+// addHook replaces the hooks of the Container by a copy modified through
+// add. Frozen Containers reject new hooks with ErrFrozen.
+func (d *defaultContainer) addHook(kind string, add func(h *hooks)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to add %s: %w", kind, ErrFrozen)
+	}
+	next := *d.currentHooks()
+	add(&next)
+	d.hooks.Store(&next)
+	return nil
+}
+
+func (d *defaultContainer) OnResolved(hook ResolvedHookFunc) error {
+	return d.addHook("resolved hook", func(h *hooks) {
+		h.resolved = append(slices.Clip(h.resolved), hook)
+	})
+}
+
+func (d *defaultContainer) AddPostProcessor(processor PostProcessorFunc) error {
+	return d.addHook("post-processor", func(h *hooks) {
+		h.postProcessors = append(slices.Clip(h.postProcessors), processor)
+	})
+}
+
+func (d *defaultContainer) postProcess(name string, value any) any {
+	for _, processor := range d.currentHooks().postProcessors {
+		value = processor(name, value)
+	}
+	return value
+}
+
+func (d *defaultContainer) OnBind(listener func(name string)) error {
+	return d.addHook("bind listener", func(h *hooks) {
+		h.bind = append(slices.Clip(h.bind), listener)
+	})
+}
+
+func (d *defaultContainer) OnResolve(listener func(name string, duration time.Duration)) error {
+	return d.addHook("resolve listener", func(h *hooks) {
+		h.resolve = append(slices.Clip(h.resolve), listener)
+	})
+}
+
+func (d *defaultContainer) OnMiss(listener func(name string)) error {
+	return d.addHook("miss listener", func(h *hooks) {
+		h.miss = append(slices.Clip(h.miss), listener)
+	})
+}
+
+func (d *defaultContainer) Alias(name, target string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to alias %s service: %w", name, ErrFrozen)
+	}
+	if d.locked.Load() {
+		return errors.New("service container locked. no more services can be bound")
+	}
+	if _, ok := d.table.service(name); ok {
+		return errors.New(fmt.Sprintf("service with name %s already bound", name))
+	}
+	if _, ok := d.table.alias(name); ok {
+		return errors.New(fmt.Sprintf("service with name %s already bound", name))
+	}
+	if err := d.validateName(name); err != nil {
+		return err
+	}
+	for next, ok := target, true; ok; next, ok = d.table.alias(next) {
+		if next == name {
+			chain := append([]string{name}, d.aliasChain(target)...)
+			return errors.New(fmt.Sprintf("alias %s for %s would create a cycle %s", name, target, strings.Join(chain, " -> ")))
+		}
+	}
+	d.storeAlias(name, target)
+	return nil
+}
+
+// aliasChain returns the names visited, when resolving the given name
+// through its aliases, starting with the name itself. The caller must
+// hold the mutex of the Container.
+func (d *defaultContainer) aliasChain(name string) []string {
+	chain := []string{name}
+	seen := map[string]bool{name: true}
+	for next, ok := d.table.alias(name); ok; next, ok = d.table.alias(next) {
+		chain = append(chain, next)
+		if seen[next] {
+			break
+		}
+		seen[next] = true
+	}
+	return chain
+}
+
 func (d *defaultContainer) Resolver() ResolverFunc {
+	return d.resolver(context.Background())
+}
+
+func (d *defaultContainer) ResolverContext(ctx context.Context) ResolverFunc {
+	return d.resolver(ctx)
+}
+
+// resolver returns a ResolverFunc, which resolves dependencies within
+// the given context.
+func (d *defaultContainer) resolver(ctx context.Context) ResolverFunc {
 	return func(name string) (any, error) {
-		if _, ok := d.services[name]; !ok {
-			return nil, errors.New(fmt.Sprintf("%s service not found in container", name))
+		value, err := d.resolve(ctx, name)
+		return value, d.formatError(ctx, name, err)
+	}
+}
+
+func (d *defaultContainer) resolve(ctx context.Context, name string) (any, error) {
+	if parent := treeFrom(ctx); parent != nil {
+		return parent.record(ctx, name, d.lookup)
+	}
+	return d.lookup(ctx, name)
+}
+
+// lookup resolves the named dependency from the Scope of the resolution
+// or the bindings of the Container.
+func (d *defaultContainer) lookup(ctx context.Context, name string) (any, error) {
+	node := treeFrom(ctx)
+	if scope := scopeFrom(ctx); scope != nil {
+		value, ok, err := scope.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			node.describe(KindInstanced, true)
+			return value, nil
+		}
+	}
+	if d.prefixing.Load() {
+		name = d.localName(ctx, name)
+	}
+	if hot, ok := d.promoted(ctx, name); ok {
+		d.recordEdge(ctx, name)
+		if hot.b.deprecation != nil {
+			hot.b.deprecation.warn(d.log(), name)
+		}
+		return d.served(ctx, name, hot.value, hot.stats, time.Now())
+	}
+	generation := d.generation.Load()
+	d.mu.RLock()
+	b, ok := d.table.service(name)
+	target, aliased := d.table.alias(name)
+	mount, rest, mounted := d.mounted(name)
+	d.mu.RUnlock()
+	if !ok && aliased {
+		node.describe(KindAlias, false)
+		return d.resolve(ctx, target)
+	}
+	if !ok && mounted {
+		d.recordEdge(ctx, name)
+		return mount.ResolverContext(detach(ctx))(rest)
+	}
+	if !ok {
+		for _, listener := range d.currentHooks().miss {
+			listener(name)
+		}
+		if d.debugging(ctx) {
+			d.debug(ctx, "service not found", slog.String("service", name))
+		}
+		return nil, resolutionError(ctx, name, FailureNotFound, ErrNotFound)
+	}
+	return d.lookupBinding(ctx, generation, name, b, d.statsOf(name))
+}
+
+// lookupBinding resolves the named dependency from its binding b, which
+// was bound in the given generation of the bindings.
+func (d *defaultContainer) lookupBinding(ctx context.Context, generation uint64, name string, b *binding, stats *bindingStats) (any, error) {
+	treeFrom(ctx).describe(b.kind(), false)
+	if !visible(ctx, b) {
+		return nil, resolutionError(ctx, name, FailureNotFound, &privateError{module: b.module})
+	}
+	if err := d.rejectDraining(ctx, b); err != nil {
+		return nil, resolutionError(ctx, name, FailureConstruction, err)
+	}
+	d.recordEdge(ctx, name)
+	start := time.Now()
+	if b.deprecation != nil {
+		b.deprecation.warn(d.log(), name)
+	}
+	value, err := d.construct(ctx, name, b, stats)
+	if err != nil {
+		stats.failed()
+		d.debug(ctx, "service construction failed", slog.String("service", name), slog.Any("error", err))
+		return nil, resolutionError(ctx, name, FailureConstruction, err)
+	}
+	d.promote(generation, name, b, stats, value)
+	return d.served(ctx, name, value, stats, start)
+}
+
+// served runs the hooks and listeners for the resolved value of the named
+// dependency, before it is handed out.
+func (d *defaultContainer) served(ctx context.Context, name string, value any, stats *bindingStats, start time.Time) (any, error) {
+	h := d.currentHooks()
+	for _, hook := range h.resolved {
+		if err := hook(name, value); err != nil {
+			stats.failed()
+			d.debug(ctx, "service failed validation", slog.String("service", name), slog.Any("error", err))
+			return nil, resolutionError(ctx, name, FailureValidation, err)
 		}
-		return d.services[name](d.Resolver()), nil
 	}
+	stats.resolved()
+	duration := time.Since(start)
+	for _, listener := range h.resolve {
+		listener(name, duration)
+	}
+	if d.debugging(ctx) {
+		d.debug(ctx, "service resolved", slog.String("service", name), slog.Duration("duration", duration))
+	}
+	return value, nil
+}
+
+func (d *defaultContainer) log() *slog.Logger {
+	if d.logger == nil {
+		return slog.Default()
+	}
+	return d.logger
+}
+
+// debugging reports, whether debug messages are logged, allowing callers
+// on hot paths to skip building the attributes of discarded messages.
+func (d *defaultContainer) debugging(ctx context.Context) bool {
+	return d.log().Enabled(ctx, slog.LevelDebug)
+}
+
+// debug logs a debug message for the resolution within the given context,
+// including the trace ID of the context if available.
+func (d *defaultContainer) debug(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if !d.debugging(ctx) {
+		return
+	}
+	logger := d.log()
+	if d.traceID != nil {
+		if id := d.traceID(ctx); id != "" {
+			attrs = append(attrs, slog.String("trace_id", id))
+		}
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+}
+
+func (d *defaultContainer) construct(ctx context.Context, name string, b *binding, stats *bindingStats) (any, error) {
+	switch {
+	case b.scoped:
+		scope := scopeFrom(ctx)
+		if scope == nil {
+			return nil, errors.New(fmt.Sprintf("%s service is scoped and can only be resolved within a scope", name))
+		}
+		inst, err := scope.instanceOf(name, b)
+		if err != nil {
+			return nil, err
+		}
+		return d.once(ctx, name, b, inst, stats)
+	case b.singleton:
+		inst := d.instanceOf(b)
+		if !inst.built.Load() {
+			if err := d.executePlan(ctx, name); err != nil {
+				return nil, err
+			}
+		}
+		return d.once(withoutScope(ctx), name, b, inst, stats)
+	}
+	if err := d.resolveDependencies(ctx, name, b); err != nil {
+		return nil, err
+	}
+	value, err := d.build(ctx, name, b)
+	if err != nil {
+		return nil, err
+	}
+	if scope := scopeFrom(ctx); scope != nil {
+		scope.track(value)
+	}
+	return value, nil
+}
+
+// once constructs the value of b into the given instance, unless it was
+// constructed already.
+func (d *defaultContainer) once(ctx context.Context, name string, b *binding, inst *instance, stats *bindingStats) (any, error) {
+	if inst.built.Load() {
+		treeFrom(ctx).hit()
+		return inst.value, nil
+	}
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	if inst.built.Load() {
+		treeFrom(ctx).hit()
+		return inst.value, nil
+	}
+	if err := d.resolveDependencies(ctx, name, b); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	value, err := d.build(ctx, name, b)
+	if err != nil {
+		return nil, err
+	}
+	inst.value = value
+	stats.constructed(time.Since(start))
+	inst.built.Store(true)
+	return value, nil
+}
+
+// resolveDependencies resolves all dependencies declared through the
+// DependsOn option of b, before b is constructed.
+func (d *defaultContainer) resolveDependencies(ctx context.Context, name string, b *binding) error {
+	if len(b.dependsOn) == 0 {
+		return nil
+	}
+	ctx = context.WithValue(ctx, dependentKey{}, &dependent{name: name, module: b.module, declared: true, parent: dependentFrom(ctx)})
+	for _, dependency := range b.dependsOn {
+		if _, err := d.resolve(ctx, dependency); err != nil {
+			return fmt.Errorf("unable to resolve dependency %s of %s service: %w", dependency, name, err)
+		}
+	}
+	return nil
+}
+
+// instanceOf returns the instance holding the value of the given singleton
+// binding within the Container. Unshared singletons are constructed by
+// every Container on its own.
+func (d *defaultContainer) instanceOf(b *binding) *instance {
+	if inst, ok := d.loadInstance(b); ok {
+		return inst
+	}
+	inst, _ := d.instances.LoadOrStore(b, &instance{})
+	return inst.(*instance)
+}
+
+// loadInstance returns the instance of the given singleton binding, if it
+// was created already.
+func (d *defaultContainer) loadInstance(b *binding) (*instance, bool) {
+	if b.shared {
+		return b.instance, true
+	}
+	inst, ok := d.instances.Load(b)
+	if !ok {
+		return nil, false
+	}
+	return inst.(*instance), true
+}
+
+// build executes the factory of a dependency and applies all
+// post-processors to the constructed value.
+func (d *defaultContainer) build(ctx context.Context, name string, b *binding) (any, error) {
+	value, err := d.invokeWithin(ctx, name, b)
+	if err != nil {
+		return nil, err
+	}
+	value = d.postProcess(name, value)
+	d.trackInstance(ctx, name, value)
+	return value, nil
+}
+
+// invoke executes the factory of b labeled with the name of the
+// constructed dependency, so profiles attribute construction costs
+// to the respective binding.
+func (d *defaultContainer) invoke(ctx context.Context, name string, b *binding) (any, error) {
+	var value any
+	var err error
+	pprof.Do(ctx, pprof.Labels("godi.service", name), func(ctx context.Context) {
+		ctx = context.WithValue(ctx, dependentKey{}, &dependent{name: name, module: b.module, parent: dependentFrom(ctx)})
+		if b.poolSize > 0 {
+			ctx = context.WithValue(ctx, resolverKey{}, d.resolver)
+		}
+		value, err = b.factory(ctx, d.resolver(ctx))
+	})
+	return value, err
+}
+
+// resolverKey is the context key of the function creating ResolverFuncs
+// of the Container, which constructs a Pool. The Pool resolves the
+// dependencies of its instances through it within the context of Acquire.
+type resolverKey struct{}
+
+// resolverFrom returns the function creating ResolverFuncs of the
+// Container constructing a Pool within the given context.
+func resolverFrom(ctx context.Context) func(ctx context.Context) ResolverFunc {
+	resolver, _ := ctx.Value(resolverKey{}).(func(ctx context.Context) ResolverFunc)
+	return resolver
 }
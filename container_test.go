@@ -1,7 +1,14 @@
 package godi
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -147,3 +154,304 @@ func TestDefaultContainer_Lock(t *testing.T) {
 		t.Fatalf("Dependency can be pushed to locked container")
 	}
 }
+
+func TestDefaultContainer_OnResolved(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("port", func(resolver ResolverFunc) any {
+		return 0
+	})
+	container.MustBind("host", func(resolver ResolverFunc) any {
+		return "localhost"
+	})
+	validationErr := errors.New("port must not be zero")
+	var validated []string
+	container.OnResolved(func(name string, value any) error {
+		validated = append(validated, name)
+		if port, ok := value.(int); ok && port == 0 {
+			return validationErr
+		}
+		return nil
+	})
+
+	if _, err := container.Resolver()("host"); err != nil {
+		t.Fatalf("Valid dependency %s failed resolution: %s", "host", err)
+	}
+	_, err := container.Resolver()("port")
+	if !errors.Is(err, validationErr) {
+		t.Fatalf("Expected validation error for dependency %s, got %v", "port", err)
+	}
+	if len(validated) != 2 {
+		t.Fatalf("Expected hook to be executed %d times, got %d", 2, len(validated))
+	}
+}
+
+func TestDefaultContainer_AddPostProcessor(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	container.MustBindSingleton("shout", func(resolver ResolverFunc) any {
+		return "hey"
+	})
+	container.AddPostProcessor(func(name string, value any) any {
+		if str, ok := value.(string); ok {
+			return str + " world"
+		}
+		return value
+	})
+	container.AddPostProcessor(func(name string, value any) any {
+		if str, ok := value.(string); ok {
+			return str + "!"
+		}
+		return value
+	})
+
+	greeting := MustResolve[string]("greeting", container.Resolver())
+	if greeting != "hello world!" {
+		t.Fatalf("Post-processors not applied in order. Got %s expected %s", greeting, "hello world!")
+	}
+	MustResolve[string]("shout", container.Resolver())
+	shout := MustResolve[string]("shout", container.Resolver())
+	if shout != "hey world!" {
+		t.Fatalf("Singleton post-processed more than once. Got %s expected %s", shout, "hey world!")
+	}
+}
+
+func TestDefaultContainer_Events(t *testing.T) {
+	container := NewContainer()
+	var bound, resolved, missed []string
+	container.OnBind(func(name string) {
+		bound = append(bound, name)
+	})
+	container.OnResolve(func(name string, duration time.Duration) {
+		resolved = append(resolved, name)
+		if duration < 0 {
+			t.Fatalf("Unexpected negative resolution duration %s", duration)
+		}
+	})
+	container.OnMiss(func(name string) {
+		missed = append(missed, name)
+	})
+	handler := func(resolver ResolverFunc) any {
+		return true
+	}
+	container.MustBind("foo", handler)
+	container.MustBindSingleton("bar", handler)
+
+	resolver := container.Resolver()
+	MustResolve[bool]("foo", resolver)
+	MustResolve[bool]("bar", resolver)
+	_, _ = resolver("baz")
+
+	if fmt.Sprint(bound) != "[foo bar]" {
+		t.Fatalf("Unexpected bind events. Got %v", bound)
+	}
+	if fmt.Sprint(resolved) != "[foo bar]" {
+		t.Fatalf("Unexpected resolve events. Got %v", resolved)
+	}
+	if fmt.Sprint(missed) != "[baz]" {
+		t.Fatalf("Unexpected miss events. Got %v", missed)
+	}
+}
+
+func TestDefaultContainer_Alias(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return 1
+	})
+	if err := container.Alias("bar", "foo"); err != nil {
+		t.Fatalf("Unable to alias dependency %s: %s", "foo", err)
+	}
+	if err := container.Alias("baz", "bar"); err != nil {
+		t.Fatalf("Unable to alias dependency %s: %s", "bar", err)
+	}
+	if v := MustResolve[int]("baz", container.Resolver()); v != 1 {
+		t.Fatalf("Aliased dependency has unexpected value. Expected %d got %d", 1, v)
+	}
+
+	if err := container.Alias("foo", "baz"); err == nil {
+		t.Fatalf("Alias could override already existing dependency %s", "foo")
+	}
+	if err := container.Alias("qux", "quux"); err != nil {
+		t.Fatalf("Unable to alias dependency %s: %s", "quux", err)
+	}
+	if err := container.Alias("quux", "qux"); err == nil || !strings.Contains(err.Error(), "quux -> qux -> quux") {
+		t.Fatalf("Alias could create a cycle between %s and %s: %v", "qux", "quux", err)
+	}
+
+	err := container.Bind("baz", func(resolver ResolverFunc) any {
+		return 2
+	}, Replace())
+	if err == nil || !strings.Contains(err.Error(), "baz -> bar -> foo") {
+		t.Fatalf("Binding shadowed alias %s: %v", "baz", err)
+	}
+}
+
+type traceKey struct{}
+
+func TestDefaultContainer_ResolverContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	container := NewContainer(WithLogger(logger), WithTraceID(func(ctx context.Context) string {
+		id, _ := ctx.Value(traceKey{}).(string)
+		return id
+	}))
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return MustResolve[int]("bar", resolver)
+	})
+	container.MustBind("bar", func(resolver ResolverFunc) any {
+		return 1
+	})
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "req-42")
+	resolver := container.ResolverContext(ctx)
+	if v := MustResolve[int]("foo", resolver); v != 1 {
+		t.Fatalf("Dependency %s has unexpected value. Expected %d got %d", "foo", 1, v)
+	}
+	_, _ = resolver("baz")
+
+	for _, name := range []string{"foo", "bar", "baz"} {
+		if !strings.Contains(buf.String(), "service="+name+" ") {
+			t.Fatalf("Missing debug log for dependency %s: %q", name, buf.String())
+		}
+	}
+	if strings.Count(buf.String(), "trace_id=req-42") != 3 {
+		t.Fatalf("Expected trace id in every debug log: %q", buf.String())
+	}
+}
+
+func TestDefaultContainer_ResolverContext_Nested(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.BindCtx("user", func(ctx context.Context, resolver ResolverFunc) (any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			return nil, errors.New("deadline lost")
+		}
+		user, _ := ctx.Value(traceKey{}).(string)
+		return user, nil
+	})
+	container.MustBindSingleton("session", func(resolver ResolverFunc) any {
+		return MustResolve[string]("user", resolver)
+	})
+	container.BindWhen("greeting", func() bool { return true }, func(resolver ResolverFunc) any {
+		return "hello " + MustResolve[string]("session", resolver)
+	}, func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	container.MustBind("handler", func(resolver ResolverFunc) any {
+		return MustResolve[string]("greeting", resolver)
+	}, DependsOn("session"))
+	container.Lock()
+
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), traceKey{}, "alice"), time.Minute)
+	defer cancel()
+	scope := container.NewScope()
+	if v := MustResolve[string]("handler", scope.ResolverContext(ctx)); v != "hello alice" {
+		t.Fatalf("Context not propagated to nested binders. Expected %q got %q", "hello alice", v)
+	}
+}
+
+func TestDefaultContainer_BindCtx(t *testing.T) {
+	container := NewContainer()
+	container.BindCtx("user", func(ctx context.Context, resolver ResolverFunc) (any, error) {
+		user, ok := ctx.Value(traceKey{}).(string)
+		if !ok {
+			return nil, errors.New("no user")
+		}
+		return user, nil
+	})
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "alice")
+	if v := MustResolve[string]("user", container.ResolverContext(ctx)); v != "alice" {
+		t.Fatalf("Binder did not receive resolution context. Expected %s got %s", "alice", v)
+	}
+	if _, err := container.Resolver()("user"); err == nil || !strings.Contains(err.Error(), "no user") {
+		t.Fatalf("Binder error not reported: %v", err)
+	}
+}
+
+func TestDefaultContainer_GetOrBindSingleton(t *testing.T) {
+	container := NewContainer()
+	var count atomic.Int32
+	binder := func(resolver ResolverFunc) any {
+		count.Add(1)
+		return &closeRecorder{}
+	}
+	var wg sync.WaitGroup
+	values := make([]any, 16)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = container.GetOrBindSingleton("producer.orders", binder)
+		}(i)
+	}
+	wg.Wait()
+	if count.Load() != 1 {
+		t.Fatalf("Singleton constructed %d times", count.Load())
+	}
+	for _, value := range values {
+		if value != values[0] || value == nil {
+			t.Fatalf("Concurrent calls received different instances")
+		}
+	}
+
+	container.Lock()
+	if _, err := container.GetOrBindSingleton("producer.orders", binder); err != nil {
+		t.Fatalf("Unable to get bound singleton from locked container: %s", err)
+	}
+	if _, err := container.GetOrBindSingleton("producer.users", binder); err == nil {
+		t.Fatalf("Bound singleton to locked container")
+	}
+}
+
+func TestDefaultContainer_GetOrBindSingleton_Options(t *testing.T) {
+	container := NewContainer()
+	value, err := container.GetOrBindSingleton("producer", func(resolver ResolverFunc) any {
+		return "orders"
+	}, Qualifier("orders"), Tags("producers"))
+	if err != nil || value != "orders" {
+		t.Fatalf("Unable to get or bind qualified singleton: %v", err)
+	}
+	if v := MustResolve[string](QualifiedName("producer", "orders"), container.Resolver()); v != "orders" {
+		t.Fatalf("Singleton not bound under its qualified name. Got %s", v)
+	}
+	if names := container.Tagged("producers"); len(names) != 1 || names[0] != QualifiedName("producer", "orders") {
+		t.Fatalf("Singleton not tagged. Got %v", names)
+	}
+	if value, err = container.GetOrBindSingleton("producer", func(resolver ResolverFunc) any {
+		return "users"
+	}, Qualifier("orders")); err != nil || value != "orders" {
+		t.Fatalf("Bound qualified singleton twice: %v", err)
+	}
+}
+
+func TestDefaultContainer_Locked(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var constructions atomic.Int32
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		constructions.Add(1)
+		return "db"
+	}, Eager())
+	if container.Locked() {
+		t.Fatalf("New container reported as locked")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			container.Lock()
+		}()
+	}
+	wg.Wait()
+	if !container.Locked() {
+		t.Fatalf("Locked container not reported as locked")
+	}
+	if err := container.ResetSingleton("db"); err != nil {
+		t.Fatalf("Unable to reset singleton: %s", err)
+	}
+	container.Lock()
+	if constructions.Load() != 1 {
+		t.Fatalf("Repeated lock constructed eager singleton again")
+	}
+}
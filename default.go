@@ -0,0 +1,47 @@
+package godi
+
+import (
+	"sync"
+)
+
+var defaultMu sync.RWMutex
+var defaultInstance = NewContainer()
+
+// Default returns the package-level default Container, which is meant for
+// small programs and examples, where passing a Container around is
+// overkill. Libraries should never rely on it.
+func Default() Container {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultInstance
+}
+
+// SetDefault replaces the default Container and returns the former one.
+// Passing nil resets the default Container to a new, empty Container,
+// e.g. between tests.
+func SetDefault(c Container) Container {
+	if c == nil {
+		c = NewContainer()
+	}
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	previous := defaultInstance
+	defaultInstance = c
+	return previous
+}
+
+// Bind binds an instanced dependency to the default Container.
+func Bind(name string, binder BinderFunc, options ...BindOption) error {
+	return Default().Bind(name, binder, options...)
+}
+
+// BindSingleton binds a singleton dependency to the default Container.
+func BindSingleton(name string, binder BinderFunc, options ...BindOption) error {
+	return Default().BindSingleton(name, binder, options...)
+}
+
+// ResolveDefault resolves a dependency from the default Container, like
+// Resolve does.
+func ResolveDefault[T any](name string) (T, error) {
+	return Resolve[T](name, Default().Resolver())
+}
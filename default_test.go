@@ -0,0 +1,33 @@
+package godi
+
+import (
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	previous := SetDefault(nil)
+	defer SetDefault(previous)
+
+	if err := Bind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	}); err != nil {
+		t.Fatalf("Unable to bind to default container: %s", err)
+	}
+	if err := BindSingleton("counter", func(resolver ResolverFunc) any {
+		return 1
+	}); err != nil {
+		t.Fatalf("Unable to bind singleton to default container: %s", err)
+	}
+	if v, err := ResolveDefault[string]("greeting"); err != nil || v != "hello" {
+		t.Fatalf("Unable to resolve from default container: %v", err)
+	}
+
+	container := NewContainer()
+	SetDefault(container)
+	if Default() != container {
+		t.Fatalf("Default container not replaced")
+	}
+	if _, err := ResolveDefault[string]("greeting"); err == nil {
+		t.Fatalf("Replaced default container still resolves former bindings")
+	}
+}
@@ -0,0 +1,42 @@
+package godi
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+type deprecation struct {
+	message     string
+	replacement string
+	once        sync.Once
+}
+
+func (dep *deprecation) warn(logger *slog.Logger, name string) {
+	dep.once.Do(func() {
+		logger.Warn("deprecated service resolved",
+			slog.String("service", name),
+			slog.String("message", dep.message),
+			slog.String("replacement", dep.replacement),
+		)
+	})
+}
+
+func (d *defaultContainer) Deprecate(name, message, replacement string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to deprecate %s service: %w", name, ErrFrozen)
+	}
+	b, ok := d.table.service(name)
+	if !ok {
+		return &notFoundError{name: name}
+	}
+	next := b.copy()
+	next.deprecation = &deprecation{
+		message:     message,
+		replacement: replacement,
+	}
+	d.replace(name, b, next)
+	return nil
+}
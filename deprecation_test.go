@@ -0,0 +1,34 @@
+package godi
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDefaultContainer_Deprecate(t *testing.T) {
+	var buf bytes.Buffer
+	container := NewContainer(WithLogger(slog.New(slog.NewTextHandler(&buf, nil)))).(*defaultContainer)
+	container.MustBind("old-mailer", func(resolver ResolverFunc) any {
+		return true
+	})
+	if err := container.Deprecate("old-mailer", "uses the legacy smtp relay", "mailer"); err != nil {
+		t.Fatalf("Unable to deprecate dependency %s: %s", "old-mailer", err)
+	}
+	if err := container.Deprecate("missing", "", ""); err == nil {
+		t.Fatalf("Deprecated non existing dependency %s", "missing")
+	}
+
+	resolver := container.Resolver()
+	MustResolve[bool]("old-mailer", resolver)
+	MustResolve[bool]("old-mailer", resolver)
+
+	output := buf.String()
+	if strings.Count(output, "deprecated service resolved") != 1 {
+		t.Fatalf("Expected exactly one deprecation warning, got %q", output)
+	}
+	if !strings.Contains(output, "service=old-mailer") || !strings.Contains(output, "replacement=mailer") {
+		t.Fatalf("Deprecation warning misses service details: %q", output)
+	}
+}
@@ -0,0 +1,94 @@
+package godi
+
+import (
+	"slices"
+)
+
+// Report lists the differences between the bindings of two Containers,
+// as returned by Diff.
+type Report struct {
+	// Added lists the bindings only present in the second Container.
+	Added []BindingInfo `json:"added,omitempty"`
+	// Removed lists the bindings only present in the first Container.
+	Removed []BindingInfo `json:"removed,omitempty"`
+	// Changed lists the bindings present in both Containers, which differ.
+	Changed []BindingChange `json:"changed,omitempty"`
+}
+
+// BindingChange describes a binding, which differs between two Containers.
+type BindingChange struct {
+	Name   string      `json:"name"`
+	Before BindingInfo `json:"before"`
+	After  BindingInfo `json:"after"`
+	// Fields lists the names of all differing fields, like "kind", "tags"
+	// or "location".
+	Fields []string `json:"fields"`
+}
+
+// Empty reports, whether the Report lists no differences.
+func (r Report) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Diff compares the bindings of the Containers a and b, listing all
+// bindings added, removed or changed in b. Bindings are compared by their
+// wiring, like their kind, target, tags and bind location, but not by
+// their state, like whether a singleton was constructed already.
+func Diff(a, b Container) Report {
+	return DiffDescriptions(Describe(a), Describe(b))
+}
+
+// DiffDescriptions compares the Descriptions a and b of two Containers
+// like Diff does, e.g. descriptions served by debug endpoints of two
+// deployments.
+func DiffDescriptions(a, b Description) Report {
+	var report Report
+	previous := make(map[string]BindingInfo, len(a.Bindings))
+	for _, info := range a.Bindings {
+		previous[info.Name] = info
+	}
+	for _, info := range b.Bindings {
+		before, ok := previous[info.Name]
+		delete(previous, info.Name)
+		if !ok {
+			report.Added = append(report.Added, info)
+			continue
+		}
+		if fields := changedFields(before, info); len(fields) > 0 {
+			report.Changed = append(report.Changed, BindingChange{Name: info.Name, Before: before, After: info, Fields: fields})
+		}
+	}
+	for _, info := range a.Bindings {
+		if _, ok := previous[info.Name]; ok {
+			report.Removed = append(report.Removed, info)
+		}
+	}
+	return report
+}
+
+func changedFields(a, b BindingInfo) []string {
+	var fields []string
+	for _, field := range []struct {
+		name    string
+		changed bool
+	}{
+		{"kind", a.Kind != b.Kind},
+		{"target", a.Target != b.Target},
+		{"shared", a.Shared != b.Shared},
+		{"deprecated", a.Deprecated != b.Deprecated || a.DeprecationMessage != b.DeprecationMessage || a.Replacement != b.Replacement},
+		{"tags", !slices.Equal(a.Tags, b.Tags)},
+		{"qualifier", a.Qualifier != b.Qualifier},
+		{"dependsOn", !slices.Equal(a.DependsOn, b.DependsOn)},
+		{"type", a.Type != b.Type},
+		{"primary", a.Primary != b.Primary},
+		{"location", a.Location != b.Location},
+		{"module", a.Module != b.Module || a.Private != b.Private},
+		{"priority", a.Priority != b.Priority},
+		{"phase", a.Phase != b.Phase},
+	} {
+		if field.changed {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
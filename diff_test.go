@@ -0,0 +1,49 @@
+package godi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	handler := func(resolver ResolverFunc) any {
+		return true
+	}
+	old := NewContainer().(*defaultContainer)
+	old.MustBind("kept", handler)
+	old.MustBind("removed", handler)
+	old.MustBind("lifetime", handler)
+	old.MustBind("tagged", handler)
+
+	next := old.Clone()
+	if !Diff(old, next).Empty() {
+		t.Fatalf("Clone differs from its container")
+	}
+	if err := next.Tag("tagged", "http"); err != nil {
+		t.Fatalf("Unable to tag dependency: %s", err)
+	}
+	next.MustBindSingleton("lifetime", handler, Replace())
+	next.MustBind("added", handler)
+
+	report := Diff(old, next)
+	if len(report.Added) != 1 || report.Added[0].Name != "added" {
+		t.Fatalf("Unexpected added bindings %v", report.Added)
+	}
+	if len(report.Removed) != 0 {
+		t.Fatalf("Unexpected removed bindings %v", report.Removed)
+	}
+	if len(report.Changed) != 2 {
+		t.Fatalf("Expected 2 changed bindings, got %v", report.Changed)
+	}
+	if change := report.Changed[0]; change.Name != "lifetime" || !reflect.DeepEqual(change.Fields, []string{"kind", "location"}) {
+		t.Fatalf("Unexpected change %v", change)
+	}
+	if change := report.Changed[1]; change.Name != "tagged" || !reflect.DeepEqual(change.Fields, []string{"tags"}) {
+		t.Fatalf("Unexpected change %v", change)
+	}
+
+	reverse := Diff(next, old)
+	if len(reverse.Removed) != 1 || reverse.Removed[0].Name != "added" {
+		t.Fatalf("Unexpected removed bindings %v", reverse.Removed)
+	}
+}
@@ -0,0 +1,175 @@
+// Package ditest provides helpers for testing the wiring of a
+// godi.Container.
+//
+// SmokeTest resolves every binding of a container and fails the test with
+// the list of all broken constructions, catching most wiring regressions
+// with a single line:
+//
+//	func TestWiring(t *testing.T) {
+//		ditest.SmokeTest(t, app.Wiring(),
+//			ditest.Override("mailer", func(resolver godi.ResolverFunc) any {
+//				return &FakeMailer{}
+//			}),
+//		)
+//	}
+//
+// Resolve resolves a single dependency within a test and fails the test
+// with the full resolution chain instead of panicking.
+//
+// ClockProvider, RandProvider and FSProvider override the conventional
+// sources of time, randomness and files with deterministic ones:
+//
+//	clock := ditest.NewFakeClock(start)
+//	_ = container.Apply(godi.NewProviderSet(ditest.ClockProvider(clock), ditest.RandProvider(1)))
+package ditest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// Option configures a SmokeTest.
+type Option func(s *smokeTest)
+
+type smokeTest struct {
+	overrides map[string]godi.BinderFunc
+	skipped   map[string]bool
+}
+
+// Override replaces the binder of the named dependency within the sandbox
+// of the SmokeTest, e.g. to keep external-facing dependencies like mailers
+// or payment clients from reaching out.
+func Override(name string, binder godi.BinderFunc) Option {
+	return func(s *smokeTest) {
+		s.overrides[name] = binder
+	}
+}
+
+// Skip excludes the named dependencies from the SmokeTest. They are still
+// constructed, if other dependencies resolve them.
+func Skip(names ...string) Option {
+	return func(s *smokeTest) {
+		for _, name := range names {
+			s.skipped[name] = true
+		}
+	}
+}
+
+// SmokeTest resolves every name bound to the given Container and fails
+// the test, listing all names, which can't be resolved. Resolutions are
+// performed on a clone of the Container within a godi.Scope, so scoped
+// dependencies are resolved as well and the Container itself is left
+// untouched. The clone is closed, when the test finishes. The Container
+// must implement godi.Cloner and godi.Introspector, and godi.Swapper to
+// be overridden.
+func SmokeTest(t testing.TB, c godi.Container, options ...Option) {
+	t.Helper()
+	s := &smokeTest{
+		overrides: make(map[string]godi.BinderFunc),
+		skipped:   make(map[string]bool),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	cloner, ok := godi.AsCloner(c)
+	if !ok {
+		t.Fatalf("Unable to clone a container, which doesn't implement godi.Cloner")
+	}
+	sandbox := cloner.Clone()
+	introspector, ok := godi.AsIntrospector(sandbox)
+	if !ok {
+		t.Fatalf("Unable to list the dependencies of a container, which doesn't implement godi.Introspector")
+	}
+	if closer, ok := godi.AsCloser(sandbox); ok {
+		t.Cleanup(func() {
+			_ = closer.Close(context.Background())
+		})
+	}
+	swapper, ok := godi.AsSwapper(sandbox)
+	for name, binder := range s.overrides {
+		if !ok {
+			t.Fatalf("Unable to override %s of a container, which doesn't implement godi.Swapper", name)
+		}
+		if err := swapper.Swap(name, binder); err != nil {
+			t.Fatalf("Unable to override %s: %s", name, err)
+		}
+	}
+	resolver := sandbox.Resolver()
+	if scoper, ok := godi.AsScoper(sandbox); ok {
+		scope := scoper.NewScope()
+		defer scope.Close()
+		resolver = scope.Resolver()
+	}
+
+	names := introspector.Names()
+	var broken []string
+	for _, name := range names {
+		if s.skipped[name] {
+			continue
+		}
+		if err := resolve(resolver, name); err != nil {
+			broken = append(broken, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+	if len(broken) > 0 {
+		t.Errorf("Unable to resolve %d of %d dependencies:\n%s", len(broken), len(names), strings.Join(broken, "\n"))
+	}
+}
+
+// Resolve resolves the named dependency and converts it to the given type
+// like godi.Resolve does. If the dependency can't be resolved, including
+// panics of its binder, the test is failed with the resolution chain
+// leading to the failing dependency.
+func Resolve[T any](t testing.TB, name string, resolver godi.ResolverFunc) T {
+	t.Helper()
+	var value T
+	err := recovered(func() (err error) {
+		value, err = godi.Resolve[T](name, resolver)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unable to resolve %s (%s): %s", name, strings.Join(chain(name, err), " -> "), err)
+	}
+	return value
+}
+
+// chain returns the resolution chain from the named dependency to the
+// innermost dependency, which failed to resolve.
+func chain(name string, err error) []string {
+	var failed *godi.ResolutionError
+	for re := (*godi.ResolutionError)(nil); errors.As(err, &re); err = re.Cause {
+		failed = re
+	}
+	if failed == nil {
+		return []string{name}
+	}
+	return append(append([]string(nil), failed.Chain...), failed.Service)
+}
+
+// resolve resolves the named dependency, converting panics of binders
+// into errors.
+func resolve(resolver godi.ResolverFunc, name string) error {
+	return recovered(func() error {
+		_, err := resolver(name)
+		return err
+	})
+}
+
+// recovered runs fn, converting its panics into errors.
+func recovered(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if cause, ok := r.(error); ok {
+				err = fmt.Errorf("panic: %w", cause)
+				return
+			}
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,90 @@
+package ditest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// recorder records the failures reported to it instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSmokeTest(t *testing.T) {
+	container := godi.NewContainer()
+	container.MustBind("config", func(resolver godi.ResolverFunc) any {
+		return "config"
+	})
+	container.MustBind("mailer", func(resolver godi.ResolverFunc) any {
+		panic("no network in tests")
+	})
+	container.MustBind("session", func(resolver godi.ResolverFunc) any {
+		return godi.MustResolve[string]("config", resolver)
+	}, godi.Scoped())
+	container.MustBind("broken", func(resolver godi.ResolverFunc) any {
+		return godi.MustResolve[string]("missing", resolver)
+	})
+	container.MustBind("ignored", func(resolver godi.ResolverFunc) any {
+		panic("ignored")
+	})
+	container.Lock()
+
+	r := &recorder{TB: t}
+	SmokeTest(r, container, Skip("ignored"))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "2 of 6") ||
+		!strings.Contains(r.errors[0], "mailer: panic") || !strings.Contains(r.errors[0], "broken:") {
+		t.Fatalf("Unexpected failures %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	SmokeTest(r, container, Skip("ignored", "broken"), Override("mailer", func(resolver godi.ResolverFunc) any {
+		return "fake"
+	}))
+	if len(r.errors) != 0 {
+		t.Fatalf("Unexpected failures %v", r.errors)
+	}
+	if err := resolve(container.Resolver(), "mailer"); err == nil {
+		t.Fatalf("Override changed the container")
+	}
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestResolve(t *testing.T) {
+	container := godi.NewContainer()
+	var built int
+	container.MustBind("config", func(resolver godi.ResolverFunc) any {
+		built++
+		return "config"
+	})
+	container.MustBind("handler", func(resolver godi.ResolverFunc) any {
+		return godi.MustResolve[string]("repository", resolver)
+	})
+	container.MustBind("repository", func(resolver godi.ResolverFunc) any {
+		return godi.MustResolve[string]("missing", resolver)
+	})
+
+	if v := Resolve[string](t, "config", container.Resolver()); v != "config" || built != 1 {
+		t.Fatalf("Unexpected value %s after %d constructions", v, built)
+	}
+	r := &recorder{TB: t}
+	Resolve[string](r, "handler", container.Resolver())
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "(handler -> repository -> missing)") {
+		t.Fatalf("Unexpected failures %v", r.errors)
+	}
+	r = &recorder{TB: t}
+	Resolve[int](r, "config", container.Resolver())
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "(config)") {
+		t.Fatalf("Unexpected failures %v", r.errors)
+	}
+}
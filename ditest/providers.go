@@ -0,0 +1,133 @@
+package ditest
+
+import (
+	"math/rand"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// Conventional names of the sources of randomness and files, which
+// services resolve instead of reaching for the math/rand and os packages
+// directly. Together with godi.ClockName, the providers of ditest override
+// them with deterministic implementations.
+const (
+	RandName = "godi.rand"
+	FSName   = "godi.fs"
+)
+
+// FakeClock is a godi.Clock, which only moves when it is advanced by the
+// test, making timeouts and schedules testable without sleeping.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers map[*fakeTimer]struct{}
+}
+
+// NewFakeClock creates a FakeClock starting at the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start, timers: make(map[*fakeTimer]struct{})}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the time of the clock, once it was
+// advanced by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a godi.Timer firing, once the clock was advanced by at
+// least d.
+func (c *FakeClock) NewTimer(d time.Duration) godi.Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward by d, firing all timers, whose duration
+// elapsed.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for t := range c.timers {
+		if !t.deadline.After(c.now) {
+			t.fire(c.now)
+		}
+	}
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	_, active := t.clock.timers[t]
+	delete(t.clock.timers, t)
+	return active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	_, active := t.clock.timers[t]
+	t.deadline = t.clock.now.Add(d)
+	t.clock.timers[t] = struct{}{}
+	if d <= 0 {
+		t.fire(t.clock.now)
+	}
+	return active
+}
+
+// fire delivers the given time, unless the last one wasn't received yet,
+// and deactivates the timer. The lock of the clock must be held.
+func (t *fakeTimer) fire(now time.Time) {
+	delete(t.clock.timers, t)
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+// ClockProvider binds the given FakeClock as singleton under
+// godi.ClockName, replacing the clock of the Container, once the set is
+// applied.
+func ClockProvider(clock *FakeClock) godi.ProviderSet {
+	return godi.NewProviderSet().BindSingleton(godi.ClockName, func(resolver godi.ResolverFunc) any {
+		return clock
+	}, godi.Replace())
+}
+
+// RandProvider binds a *rand.Rand seeded with the given seed under
+// RandName, so every run of the test draws the same numbers. It's bound
+// instanced, so the sequence doesn't depend on the order dependents are
+// constructed in.
+func RandProvider(seed int64) godi.ProviderSet {
+	return godi.NewProviderSet().Bind(RandName, func(resolver godi.ResolverFunc) any {
+		return rand.New(rand.NewSource(seed))
+	}, godi.Replace())
+}
+
+// FSProvider binds an in-memory filesystem holding the given files as
+// singleton under FSName.
+func FSProvider(files fstest.MapFS) godi.ProviderSet {
+	return godi.NewProviderSet().BindSingleton(FSName, func(resolver godi.ResolverFunc) any {
+		return files
+	}, godi.Replace())
+}
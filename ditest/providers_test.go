@@ -0,0 +1,73 @@
+package ditest
+
+import (
+	"io/fs"
+	"math/rand"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+func TestProviders(t *testing.T) {
+	container := godi.NewContainer()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	set := godi.NewProviderSet(ClockProvider(clock), RandProvider(42), FSProvider(fstest.MapFS{
+		"config.json": {Data: []byte("{}")},
+	}))
+	if err := container.(godi.Composer).Apply(set); err != nil {
+		t.Fatalf("Unable to apply providers: %s", err)
+	}
+
+	if Resolve[godi.Clock](t, godi.ClockName, container.Resolver()) != clock {
+		t.Fatalf("Clock not overridden")
+	}
+	a := Resolve[*rand.Rand](t, RandName, container.Resolver()).Int()
+	b := Resolve[*rand.Rand](t, RandName, container.Resolver()).Int()
+	if a != b || a != rand.New(rand.NewSource(42)).Int() {
+		t.Fatalf("Randomness not deterministic: %d, %d", a, b)
+	}
+	data, err := fs.ReadFile(Resolve[fs.FS](t, FSName, container.Resolver()), "config.json")
+	if err != nil || string(data) != "{}" {
+		t.Fatalf("Unexpected file %s: %v", data, err)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	fired := clock.After(time.Minute)
+	select {
+	case <-fired:
+		t.Fatalf("Fired before the clock was advanced")
+	default:
+	}
+	clock.Advance(30 * time.Second)
+	select {
+	case <-fired:
+		t.Fatalf("Fired before the duration elapsed")
+	default:
+	}
+	clock.Advance(30 * time.Second)
+	if at := <-fired; !at.Equal(start.Add(time.Minute)) || !clock.Now().Equal(at) {
+		t.Fatalf("Unexpected time %s", at)
+	}
+
+	timer := clock.NewTimer(time.Second)
+	if !timer.Stop() || timer.Stop() {
+		t.Fatalf("Unexpected state of stopped timer")
+	}
+	clock.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Fatalf("Stopped timer fired")
+	default:
+	}
+	if timer.Reset(time.Second) {
+		t.Fatalf("Stopped timer reported active")
+	}
+	clock.Advance(time.Second)
+	<-timer.C()
+}
@@ -0,0 +1,24 @@
+package godi
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDraining is reported by resolutions of instanced and scoped
+// dependencies of a draining Container, see Closer.Drain.
+var ErrDraining = errors.New("service container draining")
+
+func (d *defaultContainer) Drain() {
+	d.draining.Store(true)
+}
+
+// rejectDraining reports ErrDraining for new resolutions of the instanced
+// or scoped binding b, while the Container is draining. Nested resolutions
+// of resolutions already in flight are allowed to complete.
+func (d *defaultContainer) rejectDraining(ctx context.Context, b *binding) error {
+	if !d.draining.Load() || (b.singleton && !b.scoped) || dependentFrom(ctx) != nil {
+		return nil
+	}
+	return ErrDraining
+}
@@ -0,0 +1,41 @@
+package godi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultContainer_Drain(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return "db"
+	})
+	container.MustBind("connection", func(resolver ResolverFunc) any {
+		return "connection"
+	})
+	container.MustBind("session", func(resolver ResolverFunc) any {
+		return "session"
+	}, Scoped())
+	var drained error
+	container.MustBind("request", func(resolver ResolverFunc) any {
+		container.Drain()
+		_, drained = resolver("connection")
+		return "request"
+	})
+	scope := container.NewScope()
+	MustResolve[string]("session", scope.Resolver())
+
+	MustResolve[string]("request", container.Resolver())
+	if drained != nil {
+		t.Fatalf("Resolution in flight interrupted by drain: %s", drained)
+	}
+	if _, err := container.Resolver()("connection"); !errors.Is(err, ErrDraining) {
+		t.Fatalf("Resolved instanced dependency while draining: %v", err)
+	}
+	if _, err := scope.Resolver()("session"); !errors.Is(err, ErrDraining) {
+		t.Fatalf("Resolved scoped dependency while draining: %v", err)
+	}
+	if v, err := container.Resolver()("db"); err != nil || v != "db" {
+		t.Fatalf("Unable to resolve singleton while draining: %v", err)
+	}
+}
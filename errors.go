@@ -0,0 +1,149 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNotFound is reported by resolutions of names, which are not bound
+// to the Container.
+var ErrNotFound = errors.New("service not found in container")
+
+// ErrTypeMismatch is reported by Resolve and the other helper functions,
+// if a dependency can't be converted to the requested type.
+var ErrTypeMismatch = errors.New("unable to convert to the requested type")
+
+// notFoundError reports a missing service. Callers probing for optional
+// dependencies usually discard it, so its message is only built on demand.
+type notFoundError struct {
+	name string
+}
+
+func (e *notFoundError) Error() string {
+	return e.name + " service not found in container"
+}
+
+func (e *notFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// typeMismatchError reports a dependency, which can't be converted to the
+// requested type, naming the type of the bound value. Its message is only
+// built on demand.
+type typeMismatchError struct {
+	name      string
+	requested reflect.Type
+	actual    reflect.Type
+}
+
+// mismatch reports, that the value of the named dependency can't be
+// converted to T.
+func mismatch[T any](name string, value any) *typeMismatchError {
+	return &typeMismatchError{name: name, requested: typeOf[T](), actual: reflect.TypeOf(value)}
+}
+
+func (e *typeMismatchError) Error() string {
+	actual := "nil"
+	if e.actual != nil {
+		actual = e.actual.String()
+	}
+	return fmt.Sprintf("cannot resolve %q as %s: bound value is %s", e.name, e.requested, actual)
+}
+
+func (e *typeMismatchError) Unwrap() error {
+	return ErrTypeMismatch
+}
+
+// FailureKind describes, why the resolution of a dependency failed.
+type FailureKind int
+
+const (
+	// FailureNotFound describes resolutions of names, which are not bound.
+	FailureNotFound FailureKind = iota
+	// FailureConstruction describes dependencies, which could not be
+	// constructed.
+	FailureConstruction
+	// FailureValidation describes dependencies rejected by a hook
+	// registered through Container.OnResolved.
+	FailureValidation
+)
+
+func (k FailureKind) String() string {
+	switch k {
+	case FailureNotFound:
+		return "not found"
+	case FailureConstruction:
+		return "construction"
+	case FailureValidation:
+		return "validation"
+	}
+	return fmt.Sprintf("FailureKind(%d)", int(k))
+}
+
+// ResolutionError describes a failed resolution structurally, so it can be
+// logged without parsing its message. Service is the dependency, which
+// failed to resolve, and Chain lists the dependencies, whose construction
+// resolved Service, starting with the outermost resolution. Chain is empty
+// for resolutions performed directly through a ResolverFunc.
+type ResolutionError struct {
+	Service string
+	Kind    FailureKind
+	Chain   []string
+	Cause   error
+}
+
+func (e *ResolutionError) Error() string {
+	switch e.Kind {
+	case FailureNotFound:
+		if e.Cause != ErrNotFound {
+			return e.Service + " service not found in container: " + e.Cause.Error()
+		}
+		return e.Service + " service not found in container"
+	case FailureValidation:
+		return e.Service + " service failed validation: " + e.Cause.Error()
+	}
+	return "unable to construct " + e.Service + " service: " + e.Cause.Error()
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Cause
+}
+
+// resolutionError reports the failed resolution of the named dependency
+// within the given context.
+func resolutionError(ctx context.Context, name string, kind FailureKind, cause error) *ResolutionError {
+	return &ResolutionError{
+		Service: name,
+		Kind:    kind,
+		Chain:   dependentFrom(ctx).chain(),
+		Cause:   cause,
+	}
+}
+
+// ErrorFormatterFunc rewrites the error of a failed resolution of the named
+// dependency, e.g. to localize it or to append a runbook link.
+type ErrorFormatterFunc func(name string, err error) error
+
+// WithErrorFormatter configures a formatter, which rewrites the errors of
+// all failed resolutions before they are returned by the ResolverFuncs and
+// Handles of the Container. Nested resolutions performed by binders are
+// not formatted, so every error is formatted exactly once.
+func WithErrorFormatter(formatter ErrorFormatterFunc) ContainerOption {
+	return func(d *defaultContainer) {
+		d.errorFormatter = formatter
+	}
+}
+
+// formatError applies the error formatter of the Container to the error
+// of a failed resolution performed within the given context.
+func (d *defaultContainer) formatError(ctx context.Context, name string, err error) error {
+	if err == nil || d.errorFormatter == nil {
+		return err
+	}
+	if dependentFrom(ctx) != nil {
+		return err
+	}
+	return d.errorFormatter(name, err)
+}
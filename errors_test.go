@@ -0,0 +1,137 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrNotFound(t *testing.T) {
+	container := NewContainer()
+	_ = container.Alias("store", "missing")
+	for _, name := range []string{"missing", "store"} {
+		_, err := container.Resolver()(name)
+		if !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Missing service %s not reported as ErrNotFound: %v", name, err)
+		}
+	}
+	if _, err := container.Resolver()("missing"); err.Error() != "missing service not found in container" {
+		t.Fatalf("Unexpected error message %s", err)
+	}
+}
+
+func TestErrTypeMismatch(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("port", func(resolver ResolverFunc) any {
+		return 8080
+	})
+	_, err := Resolve[string]("port", container.Resolver())
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Conversion failure not reported as ErrTypeMismatch: %v", err)
+	}
+	if err.Error() != `cannot resolve "port" as string: bound value is int` {
+		t.Fatalf("Unexpected error message %s", err)
+	}
+}
+
+func BenchmarkResolve_Probe(b *testing.B) {
+	container := NewContainer()
+	container.MustBindSingleton("port", func(resolver ResolverFunc) any {
+		return 8080
+	})
+	resolver := container.Resolver()
+	b.Run("found", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = Resolve[int]("port", resolver)
+		}
+	})
+	b.Run("missing", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = Resolve[int]("missing", resolver)
+		}
+	})
+	b.Run("mismatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = Resolve[string]("port", resolver)
+		}
+	})
+}
+
+func TestWithErrorFormatter(t *testing.T) {
+	var formatted []string
+	container := NewContainer(WithErrorFormatter(func(name string, err error) error {
+		formatted = append(formatted, name)
+		return fmt.Errorf("%w (see https://runbooks.example/%s)", err, name)
+	}))
+	container.MustBind("handler", func(resolver ResolverFunc) any {
+		return MustResolve[string]("missing", resolver)
+	})
+	container.MustBind("ok", func(resolver ResolverFunc) any {
+		return "ok"
+	})
+
+	_, err := container.Resolver()("missing")
+	if !errors.Is(err, ErrNotFound) || !strings.HasSuffix(err.Error(), "(see https://runbooks.example/missing)") {
+		t.Fatalf("Error not formatted: %v", err)
+	}
+	func() {
+		defer func() {
+			_ = recover()
+		}()
+		_, _ = container.Resolver()("handler")
+	}()
+	if h, _ := container.(*defaultContainer).Handle("ok"); h != nil {
+		_, _ = h.Resolve()
+	}
+	if !reflect.DeepEqual(formatted, []string{"missing"}) {
+		t.Fatalf("Nested or successful resolutions formatted: %v", formatted)
+	}
+}
+
+func TestResolutionError(t *testing.T) {
+	container := NewContainer()
+	var captured error
+	container.MustBind("handler", func(resolver ResolverFunc) any {
+		_, captured = resolver("repository")
+		return "handler"
+	})
+	container.MustBind("repository", nil, DependsOn("missing"))
+	container.MustBind("invalid", func(resolver ResolverFunc) any {
+		return "invalid"
+	})
+	container.OnResolved(func(name string, value any) error {
+		if name == "invalid" {
+			return errors.New("rejected")
+		}
+		return nil
+	})
+
+	MustResolve[string]("handler", container.Resolver())
+	var re *ResolutionError
+	if !errors.As(captured, &re) {
+		t.Fatalf("Nested failure not reported as ResolutionError: %v", captured)
+	}
+	if re.Service != "repository" || re.Kind != FailureConstruction || !reflect.DeepEqual(re.Chain, []string{"handler"}) {
+		t.Fatalf("Unexpected resolution error %+v", re)
+	}
+	if !errors.Is(captured, ErrNotFound) {
+		t.Fatalf("Cause of resolution error lost: %v", captured)
+	}
+
+	_, err := container.Resolver()("invalid")
+	if !errors.As(err, &re) || re.Kind != FailureValidation || len(re.Chain) != 0 {
+		t.Fatalf("Unexpected validation error %v", err)
+	}
+	if err.Error() != "invalid service failed validation: rejected" {
+		t.Fatalf("Unexpected error message %s", err)
+	}
+	_, err = container.Resolver()("missing")
+	if !errors.As(err, &re) || re.Kind != FailureNotFound || re.Kind.String() != "not found" {
+		t.Fatalf("Unexpected miss %v", err)
+	}
+}
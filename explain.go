@@ -0,0 +1,109 @@
+package godi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tree describes the resolution of a dependency including all of its
+// nested resolutions, as recorded by Introspector.Explain.
+type Tree struct {
+	Name string
+	Kind BindingKind
+	// Cached reports, whether the instance of a singleton or scoped
+	// dependency was constructed already.
+	Cached bool
+	// Provided reports, whether the value was provided by a Scope.
+	Provided bool
+	// Duration is the time the resolution took, including all nested
+	// resolutions.
+	Duration time.Duration
+	// Err is the error the resolution failed with, if any.
+	Err          error
+	Dependencies []*Tree
+
+	mu sync.Mutex
+}
+
+type treeKey struct{}
+
+func (d *defaultContainer) Explain(name string) (*Tree, error) {
+	root := &Tree{}
+	_, err := d.resolve(context.WithValue(context.Background(), treeKey{}, root), name)
+	return root.Dependencies[0], err
+}
+
+// treeFrom returns the Tree node of the resolution performed within the
+// given context, if the resolution is explained.
+func treeFrom(ctx context.Context) *Tree {
+	t, _ := ctx.Value(treeKey{}).(*Tree)
+	return t
+}
+
+// record adds a node for the resolution of the named dependency to t and
+// performs it through the given resolve function.
+func (t *Tree) record(ctx context.Context, name string, resolve func(ctx context.Context, name string) (any, error)) (any, error) {
+	node := &Tree{Name: name}
+	t.mu.Lock()
+	t.Dependencies = append(t.Dependencies, node)
+	t.mu.Unlock()
+	start := time.Now()
+	value, err := resolve(context.WithValue(ctx, treeKey{}, node), name)
+	node.mu.Lock()
+	node.Duration = time.Since(start)
+	node.Err = err
+	node.mu.Unlock()
+	return value, err
+}
+
+func (t *Tree) describe(kind BindingKind, provided bool) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Kind = kind
+	t.Provided = provided
+}
+
+func (t *Tree) hit() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Cached = true
+}
+
+// String renders the Tree indented by the depth of each resolution,
+// like:
+//
+//	handler (instanced) 1.2ms
+//	  repository (singleton) 1.1ms
+//	    db (singleton, cached) 1µs
+func (t *Tree) String() string {
+	var sb strings.Builder
+	t.render(&sb, 0)
+	return sb.String()
+}
+
+func (t *Tree) render(sb *strings.Builder, depth int) {
+	attrs := []string{t.Kind.String()}
+	if t.Provided {
+		attrs = []string{"provided"}
+	}
+	if t.Cached {
+		attrs = append(attrs, "cached")
+	}
+	fmt.Fprintf(sb, "%s%s (%s) %s", strings.Repeat("  ", depth), t.Name, strings.Join(attrs, ", "), t.Duration)
+	if t.Err != nil {
+		fmt.Fprintf(sb, " error: %s", t.Err)
+	}
+	sb.WriteString("\n")
+	for _, dependency := range t.Dependencies {
+		dependency.render(sb, depth+1)
+	}
+}
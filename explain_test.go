@@ -0,0 +1,54 @@
+package godi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultContainer_Explain(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return "db"
+	})
+	if err := container.Alias("database", "db"); err != nil {
+		t.Fatalf("Unable to bind alias: %s", err)
+	}
+	container.MustBind("repository", func(resolver ResolverFunc) any {
+		return MustResolve[string]("database", resolver) + "-repository"
+	})
+	container.MustBind("handler", func(resolver ResolverFunc) any {
+		MustResolve[string]("repository", resolver)
+		MustResolve[string]("db", resolver)
+		return "handler"
+	})
+
+	tree, err := container.Explain("handler")
+	if err != nil {
+		t.Fatalf("Unable to explain dependency: %s", err)
+	}
+	if tree.Name != "handler" || tree.Kind != KindInstanced || len(tree.Dependencies) != 2 {
+		t.Fatalf("Unexpected tree root %v", tree)
+	}
+	repository := tree.Dependencies[0]
+	if repository.Name != "repository" || len(repository.Dependencies) != 1 {
+		t.Fatalf("Unexpected repository node %v", repository)
+	}
+	alias := repository.Dependencies[0]
+	if alias.Kind != KindAlias || len(alias.Dependencies) != 1 || alias.Dependencies[0].Cached {
+		t.Fatalf("Unexpected alias node %v", alias)
+	}
+	if db := tree.Dependencies[1]; db.Name != "db" || !db.Cached {
+		t.Fatalf("Expected cached db node, got %v", db)
+	}
+	rendered := tree.String()
+	if !strings.Contains(rendered, "\n    database (alias)") || !strings.Contains(rendered, "\n  db (singleton, cached)") {
+		t.Fatalf("Unexpected rendering:\n%s", rendered)
+	}
+
+	if _, err = container.Resolver()("handler"); err != nil {
+		t.Fatalf("Unable to resolve dependency after explaining: %s", err)
+	}
+	if _, err = container.Explain("missing"); err == nil {
+		t.Fatalf("Explained missing dependency")
+	}
+}
@@ -0,0 +1,16 @@
+package godi
+
+import (
+	"errors"
+)
+
+// ErrFrozen is returned by all methods changing the bindings of a frozen
+// Container, see Freezer.Freeze.
+var ErrFrozen = errors.New("service container frozen")
+
+func (d *defaultContainer) Freeze() {
+	d.Lock()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.frozen = true
+}
@@ -0,0 +1,53 @@
+package godi
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDefaultContainer_Freeze(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return "db"
+	})
+	container.Freeze()
+	binder := func(resolver ResolverFunc) any {
+		return "other"
+	}
+	for action, err := range map[string]error{
+		"bind":      container.Bind("other", binder),
+		"swap":      container.Swap("db", binder),
+		"reset":     container.ResetSingleton("db"),
+		"alias":     container.Alias("database", "db"),
+		"tag":       container.Tag("db", "storage"),
+		"deprecate": container.Deprecate("db", "", ""),
+		"share":     container.Share("db"),
+		"hook": container.OnResolved(func(name string, value any) error {
+			return nil
+		}),
+		"post-processor": container.AddPostProcessor(func(name string, value any) any {
+			return "replaced"
+		}),
+		"bind listener":    container.OnBind(func(name string) {}),
+		"resolve listener": container.OnResolve(func(name string, duration time.Duration) {}),
+		"miss listener":    container.OnMiss(func(name string) {}),
+		"change listener":  container.OnChange(func(event ChangeEvent) {}),
+	} {
+		if !errors.Is(err, ErrFrozen) {
+			t.Fatalf("Expected %s to fail with ErrFrozen, got %v", action, err)
+		}
+	}
+	if MustResolve[string]("db", container.Resolver()) != "db" {
+		t.Fatalf("Unable to resolve from frozen container")
+	}
+	clone := container.Clone().(*defaultContainer)
+	if err := clone.AddPostProcessor(func(name string, value any) any {
+		return value
+	}); err != nil {
+		t.Fatalf("Unable to add post-processor to a clone: %s", err)
+	}
+	if err := clone.Swap("db", binder); err != nil {
+		t.Fatalf("Unable to swap dependency of a clone: %s", err)
+	}
+}
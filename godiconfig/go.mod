@@ -0,0 +1,7 @@
+module github.com/jschaefer-io/godi/godiconfig
+
+go 1.21
+
+require github.com/jschaefer-io/godi v0.0.0
+
+replace github.com/jschaefer-io/godi => ../
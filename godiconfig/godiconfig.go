@@ -0,0 +1,299 @@
+// Package godiconfig exposes configuration values as dependencies of a
+// godi.Container, so constructors can depend on configuration through
+// the container instead of global configuration singletons.
+//
+// Configuration is read from a Source, which is implemented by
+// *viper.Viper out of the box. A *koanf.Koanf instance can be adapted
+// through Koanf. Every configuration key is bound under its Name, like
+// "config.http.port", and can be resolved with the typed helpers.
+//
+//	container := godi.NewContainer()
+//	if err := godiconfig.Bind(container, viper.GetViper()); err != nil {
+//		return err
+//	}
+//	container.MustBind("server", func(resolver godi.ResolverFunc) any {
+//		port := godiconfig.MustInt(resolver, "http.port")
+//		return &http.Server{Addr: fmt.Sprintf(":%d", port)}
+//	})
+package godiconfig
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// Prefix is prepended to every configuration key to derive the name
+// of its dependency.
+const Prefix = "config."
+
+// Source provides configuration values by their key. It is
+// implemented by *viper.Viper.
+type Source interface {
+	AllKeys() []string
+	Get(key string) any
+}
+
+type koanfSource struct {
+	k interface {
+		Keys() []string
+		Get(path string) any
+	}
+}
+
+func (s koanfSource) AllKeys() []string {
+	return s.k.Keys()
+}
+
+func (s koanfSource) Get(key string) any {
+	return s.k.Get(key)
+}
+
+// Koanf adapts a *koanf.Koanf instance to a Source.
+func Koanf(k interface {
+	Keys() []string
+	Get(path string) any
+}) Source {
+	return koanfSource{k: k}
+}
+
+// Name returns the name of the dependency of the given configuration key.
+func Name(key string) string {
+	return Prefix + key
+}
+
+// Bind binds every key of the Source to the given Container. The
+// dependencies are instanced, so every resolution yields the current
+// value of the configuration key.
+func Bind(c godi.Container, source Source) error {
+	for _, key := range source.AllKeys() {
+		key := key
+		err := c.Bind(Name(key), func(resolver godi.ResolverFunc) any {
+			return source.Get(key)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Value resolves the raw value of a configuration key.
+func Value(resolver godi.ResolverFunc, key string) (any, error) {
+	return resolver(Name(key))
+}
+
+// String resolves a configuration key as string.
+func String(resolver godi.ResolverFunc, key string) (string, error) {
+	value, err := Value(resolver, key)
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case nil:
+		return "", nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// Int resolves a configuration key as int. Integer, float and string
+// values are converted. Floats with a fraction and values exceeding the
+// range of int fail to convert.
+func Int(resolver godi.ResolverFunc, key string) (int, error) {
+	value, err := Value(resolver, key)
+	if err != nil {
+		return 0, err
+	}
+	return toInt(key, value)
+}
+
+// toInt converts the value of the configuration key to int.
+func toInt(key string, value any) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int8:
+		return int(v), nil
+	case int16:
+		return int(v), nil
+	case int32:
+		return int(v), nil
+	case int64:
+		if v < math.MinInt || v > math.MaxInt {
+			return 0, rangeError(key, "int", value)
+		}
+		return int(v), nil
+	case uint:
+		if v > math.MaxInt {
+			return 0, rangeError(key, "int", value)
+		}
+		return int(v), nil
+	case uint8:
+		return int(v), nil
+	case uint16:
+		return int(v), nil
+	case uint32:
+		if uint64(v) > math.MaxInt {
+			return 0, rangeError(key, "int", value)
+		}
+		return int(v), nil
+	case uint64:
+		if v > math.MaxInt {
+			return 0, rangeError(key, "int", value)
+		}
+		return int(v), nil
+	case float32:
+		return floatToInt(key, float64(v), value)
+	case float64:
+		return floatToInt(key, v, value)
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("unable to convert config %s to int: %w", key, err)
+		}
+		return i, nil
+	}
+	return 0, conversionError(key, "int", value)
+}
+
+// floatToInt converts the float f of the configuration key to int, if it
+// has no fraction and is within the range of int.
+func floatToInt(key string, f float64, value any) (int, error) {
+	if f != math.Trunc(f) {
+		return 0, errors.New(fmt.Sprintf("unable to convert config %s to int. %v has a fraction", key, value))
+	}
+	if f < math.MinInt || f >= math.MaxInt {
+		return 0, rangeError(key, "int", value)
+	}
+	return int(f), nil
+}
+
+// Float64 resolves a configuration key as float64. Integer, float and
+// string values are converted.
+func Float64(resolver godi.ResolverFunc, key string) (float64, error) {
+	value, err := Value(resolver, key)
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to convert config %s to float64: %w", key, err)
+		}
+		return f, nil
+	case uint64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	}
+	i, err := toInt(key, value)
+	if err != nil {
+		return 0, conversionError(key, "float64", value)
+	}
+	return float64(i), nil
+}
+
+// Bool resolves a configuration key as bool. String values are
+// converted with strconv.ParseBool.
+func Bool(resolver godi.ResolverFunc, key string) (bool, error) {
+	value, err := Value(resolver, key)
+	if err != nil {
+		return false, err
+	}
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("unable to convert config %s to bool: %w", key, err)
+		}
+		return b, nil
+	}
+	return false, conversionError(key, "bool", value)
+}
+
+// Duration resolves a configuration key as time.Duration. String values
+// are converted with time.ParseDuration, integers are taken as nanoseconds.
+func Duration(resolver godi.ResolverFunc, key string) (time.Duration, error) {
+	value, err := Value(resolver, key)
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("unable to convert config %s to time.Duration: %w", key, err)
+		}
+		return d, nil
+	}
+	i, err := toInt(key, value)
+	if err != nil {
+		return 0, conversionError(key, "time.Duration", value)
+	}
+	return time.Duration(i), nil
+}
+
+// MustString is like String, but panics if the value can't be resolved.
+func MustString(resolver godi.ResolverFunc, key string) string {
+	value, err := String(resolver, key)
+	return must(key, value, err)
+}
+
+// MustInt is like Int, but panics if the value can't be resolved.
+func MustInt(resolver godi.ResolverFunc, key string) int {
+	value, err := Int(resolver, key)
+	return must(key, value, err)
+}
+
+// MustFloat64 is like Float64, but panics if the value can't be resolved.
+func MustFloat64(resolver godi.ResolverFunc, key string) float64 {
+	value, err := Float64(resolver, key)
+	return must(key, value, err)
+}
+
+// MustBool is like Bool, but panics if the value can't be resolved.
+func MustBool(resolver godi.ResolverFunc, key string) bool {
+	value, err := Bool(resolver, key)
+	return must(key, value, err)
+}
+
+// MustDuration is like Duration, but panics if the value can't be resolved.
+func MustDuration(resolver godi.ResolverFunc, key string) time.Duration {
+	value, err := Duration(resolver, key)
+	return must(key, value, err)
+}
+
+// must panics with err, after passing it to godi.ReportPanic for the
+// configuration key.
+func must[T any](key string, value T, err error) T {
+	if err != nil {
+		godi.ReportPanic(Name(key), err)
+		panic(err)
+	}
+	return value
+}
+
+func rangeError(key, typ string, value any) error {
+	return errors.New(fmt.Sprintf("unable to convert config %s to %s. %v is out of range", key, typ, value))
+}
+
+func conversionError(key, typ string, value any) error {
+	return errors.New(fmt.Sprintf("unable to convert config %s of type %T to %s", key, value, typ))
+}
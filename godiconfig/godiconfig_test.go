@@ -0,0 +1,135 @@
+package godiconfig
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+type mapSource map[string]any
+
+func (m mapSource) AllKeys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (m mapSource) Get(key string) any {
+	return m[key]
+}
+
+type koanfLike map[string]any
+
+func (k koanfLike) Keys() []string {
+	return mapSource(k).AllKeys()
+}
+
+func (k koanfLike) Get(path string) any {
+	return k[path]
+}
+
+func TestBind(t *testing.T) {
+	source := mapSource{
+		"http.port":    "8080",
+		"http.timeout": "5s",
+		"db.dsn":       "postgres://localhost",
+		"db.pool":      float64(10),
+		"debug":        "true",
+	}
+	container := godi.NewContainer()
+	if err := Bind(container, source); err != nil {
+		t.Fatalf("Unable to bind config source: %s", err)
+	}
+	resolver := container.Resolver()
+
+	if port := MustInt(resolver, "http.port"); port != 8080 {
+		t.Fatalf("Unexpected port %d", port)
+	}
+	if timeout := MustDuration(resolver, "http.timeout"); timeout != 5*time.Second {
+		t.Fatalf("Unexpected timeout %s", timeout)
+	}
+	if dsn := MustString(resolver, "db.dsn"); dsn != "postgres://localhost" {
+		t.Fatalf("Unexpected dsn %s", dsn)
+	}
+	if pool := MustInt(resolver, "db.pool"); pool != 10 {
+		t.Fatalf("Unexpected pool size %d", pool)
+	}
+	if pool := MustFloat64(resolver, "db.pool"); pool != 10 {
+		t.Fatalf("Unexpected pool size %f", pool)
+	}
+	if !MustBool(resolver, "debug") {
+		t.Fatalf("Expected debug to be enabled")
+	}
+	if _, err := Int(resolver, "db.dsn"); err == nil {
+		t.Fatalf("Converted non numeric string to int")
+	}
+	if _, err := String(resolver, "missing"); err == nil {
+		t.Fatalf("Resolved non existing config %s", "missing")
+	}
+
+	source["http.port"] = 9090
+	if port := MustInt(resolver, "http.port"); port != 9090 {
+		t.Fatalf("Config value not resolved live. Got %d", port)
+	}
+}
+
+func TestKoanf(t *testing.T) {
+	container := godi.NewContainer()
+	if err := Bind(container, Koanf(koanfLike{"app.name": "godi"})); err != nil {
+		t.Fatalf("Unable to bind config source: %s", err)
+	}
+	if name := MustString(container.Resolver(), "app.name"); name != "godi" {
+		t.Fatalf("Unexpected app name %s", name)
+	}
+	if _, err := container.Resolver()(Name("app.name")); err != nil {
+		t.Fatalf("Config not bound under its name: %s", err)
+	}
+}
+
+func TestInt_Lossy(t *testing.T) {
+	container := godi.NewContainer()
+	source := mapSource{
+		"ratio": 1.5,
+		"huge":  uint64(math.MaxUint64),
+		"big":   float64(math.MaxUint64),
+	}
+	if err := Bind(container, source); err != nil {
+		t.Fatalf("Unable to bind config source: %s", err)
+	}
+	for key := range source {
+		if value, err := Int(container.Resolver(), key); err == nil {
+			t.Fatalf("Converted config %s to int %d", key, value)
+		}
+	}
+	if ratio := MustFloat64(container.Resolver(), "ratio"); ratio != 1.5 {
+		t.Fatalf("Unexpected ratio %f", ratio)
+	}
+}
+
+func TestMustInt_ReportsPanic(t *testing.T) {
+	var reported []godi.PanicInfo
+	godi.SetPanicHandler(func(info godi.PanicInfo) {
+		reported = append(reported, info)
+	})
+	defer godi.SetPanicHandler(nil)
+
+	container := godi.NewContainer()
+	if err := Bind(container, mapSource{"name": "godi"}); err != nil {
+		t.Fatalf("Unable to bind config source: %s", err)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected MustInt to panic")
+			}
+		}()
+		MustInt(container.Resolver(), "name")
+	}()
+	if len(reported) != 1 || reported[0].Name != Name("name") || reported[0].Err == nil {
+		t.Fatalf("Unexpected reported panics %v", reported)
+	}
+}
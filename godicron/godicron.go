@@ -0,0 +1,105 @@
+// Package godicron runs scheduled jobs resolved from a godi.Container.
+//
+// Jobs are dependencies tagged with JobTag, which resolve to a Job. The
+// Scheduler discovers all jobs of the container, runs each of them in its
+// interval within a fresh godi.Scope and waits for running jobs to finish
+// once it is stopped.
+//
+//	container.MustBind("cleanup", func(resolver godi.ResolverFunc) any {
+//		return &CleanupJob{}
+//	})
+//	container.Tag("cleanup", godicron.JobTag)
+//
+//	scheduler := godicron.New(container)
+//	go scheduler.Run(ctx)
+package godicron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// JobTag is the tag, which marks dependencies as jobs of the Scheduler.
+const JobTag = "job"
+
+// Job is a task executed periodically by the Scheduler.
+type Job interface {
+	// Interval returns the duration between two runs of the Job.
+	Interval() time.Duration
+	// Run executes the Job. The resolver resolves dependencies within
+	// the scope of the current run.
+	Run(ctx context.Context, resolver godi.ResolverFunc) error
+}
+
+// Scheduler discovers and runs the jobs of a Container.
+type Scheduler struct {
+	container godi.Container
+	// OnError receives errors returned by runs of the jobs, if set.
+	OnError func(name string, err error)
+}
+
+// New creates a Scheduler for the jobs of the given Container.
+func New(c godi.Container) *Scheduler {
+	return &Scheduler{container: c}
+}
+
+// Run discovers all jobs and runs them until the given context is done.
+// Every job is resolved again for each run within a new scope, which is
+// closed after the run. Runs of a single job never overlap. Once the
+// context is done, Run waits for all running jobs to finish.
+func (s *Scheduler) Run(ctx context.Context) error {
+	names := s.container.Tagged(JobTag)
+	intervals := make(map[string]time.Duration, len(names))
+	for _, name := range names {
+		job, err := godi.Resolve[Job](name, s.container.ResolverContext(ctx))
+		if err != nil {
+			return fmt.Errorf("unable to resolve job %s: %w", name, err)
+		}
+		if job.Interval() <= 0 {
+			return errors.New(fmt.Sprintf("job %s has no positive interval", name))
+		}
+		intervals[name] = job.Interval()
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string, interval time.Duration) {
+			defer wg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				if err := s.runOnce(ctx, name); err != nil && s.OnError != nil {
+					s.OnError(name, err)
+				}
+			}
+		}(name, intervals[name])
+	}
+	wg.Wait()
+	return nil
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, name string) error {
+	scoper, ok := godi.AsScoper(s.container)
+	if !ok {
+		return errors.New("unable to run job. container doesn't implement godi.Scoper")
+	}
+	scope := scoper.NewScope()
+	defer scope.Close()
+	resolver := scope.ResolverContext(ctx)
+	job, err := godi.Resolve[Job](name, resolver)
+	if err != nil {
+		return err
+	}
+	return job.Run(ctx, resolver)
+}
@@ -0,0 +1,82 @@
+package godicron
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jschaefer-io/godi"
+)
+
+type countingJob struct {
+	runs *atomic.Int32
+	fail bool
+}
+
+func (j *countingJob) Interval() time.Duration {
+	return time.Millisecond
+}
+
+func (j *countingJob) Run(ctx context.Context, resolver godi.ResolverFunc) error {
+	j.runs.Add(1)
+	if j.fail {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func TestScheduler_Run(t *testing.T) {
+	var runs, failures atomic.Int32
+	container := godi.NewContainer()
+	container.MustBind("counter", func(resolver godi.ResolverFunc) any {
+		return &countingJob{runs: &runs}
+	})
+	container.MustBind("failing", func(resolver godi.ResolverFunc) any {
+		return &countingJob{runs: &failures, fail: true}
+	})
+	container.MustBind("not-a-job", func(resolver godi.ResolverFunc) any {
+		return true
+	})
+	for _, name := range []string{"counter", "failing"} {
+		if err := container.Tag(name, JobTag); err != nil {
+			t.Fatalf("Unable to tag job %s: %s", name, err)
+		}
+	}
+
+	var reported atomic.Int32
+	scheduler := New(container)
+	scheduler.OnError = func(name string, err error) {
+		if name != "failing" {
+			t.Errorf("Unexpected error of job %s: %s", name, err)
+		}
+		reported.Add(1)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 || failures.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Jobs not run. Got %d and %d runs", runs.Load(), failures.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Scheduler failed: %s", err)
+	}
+	if reported.Load() != failures.Load() {
+		t.Fatalf("Expected %d reported errors, got %d", failures.Load(), reported.Load())
+	}
+
+	if err := container.Tag("not-a-job", JobTag); err != nil {
+		t.Fatalf("Unable to tag dependency: %s", err)
+	}
+	if err := New(container).Run(context.Background()); err == nil {
+		t.Fatalf("Scheduler accepted dependency, which is not a job")
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/jschaefer-io/godi/godidig
+
+go 1.21
+
+require (
+	github.com/jschaefer-io/godi v0.0.0
+	go.uber.org/dig v1.19.0
+)
+
+replace github.com/jschaefer-io/godi => ../
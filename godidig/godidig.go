@@ -0,0 +1,50 @@
+// Package godidig bridges uber/dig containers and godi containers, so
+// applications can migrate from dig to godi incrementally instead of
+// rewriting all constructors at once.
+//
+// Bind exposes a type provided by a dig container as a named godi
+// dependency. Provide exposes a named godi dependency to the constructors
+// of a dig container.
+//
+//	godidig.Bind[*sql.DB](container, "db", digContainer)
+//	godidig.Provide[*Mailer](digContainer, "mailer", container.Resolver())
+package godidig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jschaefer-io/godi"
+	"go.uber.org/dig"
+)
+
+// Bind binds the named dependency to the godi Container, resolving the
+// value of type T from the dig container on every resolution. As dig
+// constructs every type only once, all resolutions return the same value.
+// Resolutions fail with the error of the dig container, if it is unable
+// to construct T.
+func Bind[T any](c godi.Container, name string, dc *dig.Container) error {
+	return c.BindCtx(name, func(ctx context.Context, resolver godi.ResolverFunc) (any, error) {
+		return Invoke[T](dc)
+	})
+}
+
+// Provide provides the type T to the dig container, resolving the named
+// dependency through the given godi ResolverFunc.
+func Provide[T any](dc *dig.Container, name string, resolver godi.ResolverFunc) error {
+	return dc.Provide(func() (T, error) {
+		return godi.Resolve[T](name, resolver)
+	})
+}
+
+// Invoke returns the value of type T constructed by the dig container.
+func Invoke[T any](dc *dig.Container) (T, error) {
+	var value T
+	err := dc.Invoke(func(v T) {
+		value = v
+	})
+	if err != nil {
+		return value, fmt.Errorf("unable to resolve %T from dig container: %w", value, err)
+	}
+	return value, nil
+}
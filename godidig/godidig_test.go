@@ -0,0 +1,71 @@
+package godidig
+
+import (
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+	"go.uber.org/dig"
+)
+
+type mailer struct {
+	host string
+}
+
+type newsletter struct {
+	mailer *mailer
+}
+
+func TestBind(t *testing.T) {
+	dc := dig.New()
+	if err := dc.Provide(func() *mailer {
+		return &mailer{host: "smtp"}
+	}); err != nil {
+		t.Fatalf("Unable to provide to dig container: %s", err)
+	}
+	container := godi.NewContainer()
+	if err := Bind[*mailer](container, "mailer", dc); err != nil {
+		t.Fatalf("Unable to bind dig type: %s", err)
+	}
+	first := godi.MustResolve[*mailer]("mailer", container.Resolver())
+	if first.host != "smtp" || first != godi.MustResolve[*mailer]("mailer", container.Resolver()) {
+		t.Fatalf("Unexpected value resolved from dig container")
+	}
+
+	if err := Bind[*newsletter](container, "newsletter", dc); err != nil {
+		t.Fatalf("Unable to bind dig type: %s", err)
+	}
+	if _, err := container.Resolver()("newsletter"); err == nil {
+		t.Fatalf("Resolved type missing in dig container")
+	}
+}
+
+func TestProvide(t *testing.T) {
+	container := godi.NewContainer()
+	container.MustBindSingleton("mailer", func(resolver godi.ResolverFunc) any {
+		return &mailer{host: "smtp"}
+	})
+	dc := dig.New()
+	if err := Provide[*mailer](dc, "mailer", container.Resolver()); err != nil {
+		t.Fatalf("Unable to provide godi dependency: %s", err)
+	}
+	if err := dc.Provide(func(m *mailer) *newsletter {
+		return &newsletter{mailer: m}
+	}); err != nil {
+		t.Fatalf("Unable to provide to dig container: %s", err)
+	}
+	n, err := Invoke[*newsletter](dc)
+	if err != nil {
+		t.Fatalf("Unable to invoke dig container: %s", err)
+	}
+	if n.mailer != godi.MustResolve[*mailer]("mailer", container.Resolver()) {
+		t.Fatalf("Dig constructor did not receive godi dependency")
+	}
+
+	missing := dig.New()
+	if err = Provide[*mailer](missing, "missing", container.Resolver()); err != nil {
+		t.Fatalf("Unable to provide godi dependency: %s", err)
+	}
+	if _, err = Invoke[*mailer](missing); err == nil {
+		t.Fatalf("Invoked dig container with missing godi dependency")
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/jschaefer-io/godi/godido
+
+go 1.21
+
+require (
+	github.com/jschaefer-io/godi v0.0.0
+	github.com/samber/do v1.6.0
+)
+
+replace github.com/jschaefer-io/godi => ../
@@ -0,0 +1,51 @@
+// Package godido lets godi containers and samber/do injectors resolve
+// services from each other, easing the coexistence of both in codebases
+// which adopted do for parts of the application.
+//
+// Bind and BindNamed expose services of a do injector, identified by their
+// type or name, as named godi dependencies. Provide and ProvideNamed
+// expose named godi dependencies as services of a do injector.
+//
+//	godido.Bind[*sql.DB](container, "db", injector)
+//	godido.ProvideNamed[*Mailer](injector, "mailer", "mailer", container.Resolver())
+package godido
+
+import (
+	"github.com/jschaefer-io/godi"
+	"github.com/samber/do"
+)
+
+// Bind binds the named dependency to the godi Container, invoking the
+// service of type T from the do injector on every resolution. As do
+// services are lazy singletons, all resolutions return the same value.
+func Bind[T any](c godi.Container, name string, i *do.Injector) error {
+	return c.Bind(name, func(resolver godi.ResolverFunc) any {
+		return do.MustInvoke[T](i)
+	})
+}
+
+// BindNamed is like Bind, but invokes the do service of the given name.
+func BindNamed[T any](c godi.Container, name string, i *do.Injector, service string) error {
+	return c.Bind(name, func(resolver godi.ResolverFunc) any {
+		return do.MustInvokeNamed[T](i, service)
+	})
+}
+
+// Provide provides the type T to the do injector, resolving the named
+// dependency through the given godi ResolverFunc. Like do.Provide, it
+// panics if the injector declares the type already.
+func Provide[T any](i *do.Injector, name string, resolver godi.ResolverFunc) {
+	do.Provide(i, provider[T](name, resolver))
+}
+
+// ProvideNamed is like Provide, but provides the do service of the
+// given name.
+func ProvideNamed[T any](i *do.Injector, service, name string, resolver godi.ResolverFunc) {
+	do.ProvideNamed(i, service, provider[T](name, resolver))
+}
+
+func provider[T any](name string, resolver godi.ResolverFunc) do.Provider[T] {
+	return func(*do.Injector) (T, error) {
+		return godi.Resolve[T](name, resolver)
+	}
+}
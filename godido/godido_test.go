@@ -0,0 +1,57 @@
+package godido
+
+import (
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+	"github.com/samber/do"
+)
+
+type mailer struct {
+	host string
+}
+
+func TestBind(t *testing.T) {
+	injector := do.New()
+	do.Provide(injector, func(*do.Injector) (*mailer, error) {
+		return &mailer{host: "smtp"}, nil
+	})
+	do.ProvideNamedValue(injector, "backup", &mailer{host: "backup"})
+
+	container := godi.NewContainer()
+	if err := Bind[*mailer](container, "mailer", injector); err != nil {
+		t.Fatalf("Unable to bind do service: %s", err)
+	}
+	if err := BindNamed[*mailer](container, "backup-mailer", injector, "backup"); err != nil {
+		t.Fatalf("Unable to bind named do service: %s", err)
+	}
+	first := godi.MustResolve[*mailer]("mailer", container.Resolver())
+	if first.host != "smtp" || first != godi.MustResolve[*mailer]("mailer", container.Resolver()) {
+		t.Fatalf("Unexpected value resolved from do injector")
+	}
+	if godi.MustResolve[*mailer]("backup-mailer", container.Resolver()).host != "backup" {
+		t.Fatalf("Unexpected value resolved from named do service")
+	}
+}
+
+func TestProvide(t *testing.T) {
+	container := godi.NewContainer()
+	container.MustBindSingleton("mailer", func(resolver godi.ResolverFunc) any {
+		return &mailer{host: "smtp"}
+	})
+	injector := do.New()
+	Provide[*mailer](injector, "mailer", container.Resolver())
+	ProvideNamed[*mailer](injector, "primary", "mailer", container.Resolver())
+	ProvideNamed[*mailer](injector, "missing", "missing", container.Resolver())
+
+	expected := godi.MustResolve[*mailer]("mailer", container.Resolver())
+	if m, err := do.Invoke[*mailer](injector); err != nil || m != expected {
+		t.Fatalf("Unable to invoke godi dependency from do injector: %v", err)
+	}
+	if m, err := do.InvokeNamed[*mailer](injector, "primary"); err != nil || m != expected {
+		t.Fatalf("Unable to invoke named godi dependency from do injector: %v", err)
+	}
+	if _, err := do.InvokeNamed[*mailer](injector, "missing"); err == nil {
+		t.Fatalf("Invoked missing godi dependency from do injector")
+	}
+}
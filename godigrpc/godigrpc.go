@@ -0,0 +1,50 @@
+// Package godigrpc registers gRPC services resolved from a godi.Container.
+//
+// Services are dependencies tagged with ServiceTag, which resolve to
+// a Service. Register discovers all services of the container and
+// registers them on a server during startup, keeping the service wiring
+// entirely inside the container.
+//
+//	container.MustBindSingleton("greeter", func(resolver godi.ResolverFunc) any {
+//		return &GreeterService{}
+//	})
+//	container.Tag("greeter", godigrpc.ServiceTag)
+//
+//	server := grpc.NewServer()
+//	err := godigrpc.Register(container, server)
+package godigrpc
+
+import (
+	"fmt"
+
+	"github.com/jschaefer-io/godi"
+	"google.golang.org/grpc"
+)
+
+// ServiceTag is the tag, which marks dependencies as gRPC services.
+const ServiceTag = "grpc-service"
+
+// Service is a gRPC service implementation, which registers itself,
+// usually by calling the generated RegisterXServer function.
+type Service interface {
+	Register(registrar grpc.ServiceRegistrar)
+}
+
+// Register resolves all services of the Container and registers them on
+// the given registrar in order of their names. An error is returned and
+// nothing is registered, if a service can't be resolved.
+func Register(c godi.Container, registrar grpc.ServiceRegistrar) error {
+	names := c.Tagged(ServiceTag)
+	services := make([]Service, 0, len(names))
+	for _, name := range names {
+		service, err := godi.Resolve[Service](name, c.Resolver())
+		if err != nil {
+			return fmt.Errorf("unable to resolve grpc service %s: %w", name, err)
+		}
+		services = append(services, service)
+	}
+	for _, service := range services {
+		service.Register(registrar)
+	}
+	return nil
+}
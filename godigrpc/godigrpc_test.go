@@ -0,0 +1,46 @@
+package godigrpc
+
+import (
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+	"google.golang.org/grpc"
+)
+
+type greeterServer interface{}
+
+type greeter struct{}
+
+func (g *greeter) Register(registrar grpc.ServiceRegistrar) {
+	registrar.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Greeter",
+		HandlerType: (*greeterServer)(nil),
+	}, g)
+}
+
+func TestRegister(t *testing.T) {
+	container := godi.NewContainer()
+	container.MustBindSingleton("greeter", func(resolver godi.ResolverFunc) any {
+		return &greeter{}
+	})
+	if err := container.Tag("greeter", ServiceTag); err != nil {
+		t.Fatalf("Unable to tag service: %s", err)
+	}
+	server := grpc.NewServer()
+	if err := Register(container, server); err != nil {
+		t.Fatalf("Unable to register services: %s", err)
+	}
+	if _, ok := server.GetServiceInfo()["test.Greeter"]; !ok {
+		t.Fatalf("Service not registered on server")
+	}
+
+	container.MustBind("broken", func(resolver godi.ResolverFunc) any {
+		return "not a service"
+	})
+	if err := container.Tag("broken", ServiceTag); err != nil {
+		t.Fatalf("Unable to tag service: %s", err)
+	}
+	if err := Register(container, grpc.NewServer()); err == nil {
+		t.Fatalf("Registered dependency, which is not a service")
+	}
+}
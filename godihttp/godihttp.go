@@ -0,0 +1,62 @@
+// Package godihttp mounts HTTP handlers resolved from a godi.Container.
+//
+// Handlers are dependencies tagged with HandlerTag, which resolve to
+// a Route. Mount discovers all routes of the container and registers them
+// on a mux, so adding an endpoint is just adding a binding.
+//
+//	container.MustBind("users", func(resolver godi.ResolverFunc) any {
+//		return &UsersHandler{}
+//	})
+//	container.Tag("users", godihttp.HandlerTag)
+//
+//	mux := http.NewServeMux()
+//	err := godihttp.Mount(container, mux)
+package godihttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// HandlerTag is the tag, which marks dependencies as routes to be mounted.
+const HandlerTag = "http-handler"
+
+// Route is an http.Handler serving the requests matching its pattern.
+type Route interface {
+	http.Handler
+	// Pattern returns the pattern the Route is mounted with,
+	// like "/users/".
+	Pattern() string
+}
+
+// Mux is implemented by *http.ServeMux and most third party routers.
+type Mux interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// Mount resolves all routes of the Container and registers them on the
+// given Mux in order of their names. An error is returned and nothing is
+// registered, if a route can't be resolved or two routes share a pattern.
+func Mount(c godi.Container, mux Mux) error {
+	names := c.Tagged(HandlerTag)
+	routes := make([]Route, 0, len(names))
+	patterns := make(map[string]string, len(names))
+	for _, name := range names {
+		route, err := godi.Resolve[Route](name, c.Resolver())
+		if err != nil {
+			return fmt.Errorf("unable to resolve route %s: %w", name, err)
+		}
+		if other, ok := patterns[route.Pattern()]; ok {
+			return errors.New(fmt.Sprintf("routes %s and %s share pattern %s", other, name, route.Pattern()))
+		}
+		patterns[route.Pattern()] = name
+		routes = append(routes, route)
+	}
+	for _, route := range routes {
+		mux.Handle(route.Pattern(), route)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package godihttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+type route struct {
+	pattern string
+	body    string
+}
+
+func (r *route) Pattern() string {
+	return r.pattern
+}
+
+func (r *route) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(r.body))
+}
+
+type recordingMux struct {
+	patterns []string
+}
+
+func (m *recordingMux) Handle(pattern string, _ http.Handler) {
+	m.patterns = append(m.patterns, pattern)
+}
+
+func bindRoute(t *testing.T, c godi.Container, name string, r *route) {
+	c.MustBind(name, func(resolver godi.ResolverFunc) any {
+		return r
+	})
+	if err := c.Tag(name, HandlerTag); err != nil {
+		t.Fatalf("Unable to tag route %s: %s", name, err)
+	}
+}
+
+func TestMount(t *testing.T) {
+	container := godi.NewContainer()
+	bindRoute(t, container, "users", &route{pattern: "/users", body: "users"})
+	bindRoute(t, container, "health", &route{pattern: "/health", body: "ok"})
+
+	recorder := &recordingMux{}
+	if err := Mount(container, recorder); err != nil {
+		t.Fatalf("Unable to mount routes: %s", err)
+	}
+	if !reflect.DeepEqual(recorder.patterns, []string{"/health", "/users"}) {
+		t.Fatalf("Unexpected mount order %v", recorder.patterns)
+	}
+
+	mux := http.NewServeMux()
+	if err := Mount(container, mux); err != nil {
+		t.Fatalf("Unable to mount routes: %s", err)
+	}
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/users", nil))
+	if response.Body.String() != "users" {
+		t.Fatalf("Unexpected response %s", response.Body.String())
+	}
+
+	bindRoute(t, container, "duplicate", &route{pattern: "/users"})
+	recorder = &recordingMux{}
+	if err := Mount(container, recorder); err == nil || len(recorder.patterns) > 0 {
+		t.Fatalf("Mounted routes sharing a pattern")
+	}
+}
@@ -0,0 +1,164 @@
+// Package godimsg provides per-message scopes for message driven
+// applications consuming from Kafka, NATS, SQS or similar brokers.
+//
+// Every consumed message is handled within its own godi.Scope. The message
+// itself is provided to the scope under MessageName and its metadata, like
+// headers, topic or delivery attempt, under MetadataName. Instanced
+// dependencies resolved while handling the message can therefore depend on
+// message specific values. The scope is closed once the handler finished.
+//
+//	handle := godimsg.Handler(container, func(msg *kafka.Message) map[string]any {
+//		return map[string]any{"topic": *msg.TopicPartition.Topic}
+//	}, func(ctx context.Context, msg *kafka.Message, resolver godi.ResolverFunc) error {
+//		processor := godi.MustResolve[*OrderProcessor]("order-processor", resolver)
+//		return processor.Process(ctx, msg.Value)
+//	})
+//
+// Consumers bound to the container and tagged with ConsumerTag are wired
+// to their topics by Subscribe, giving message driven applications the
+// same auto-wiring routers get.
+//
+//	container.MustBind("order-consumer", func(resolver godi.ResolverFunc) any {
+//		return &OrderConsumer{}
+//	})
+//	container.Tag("order-consumer", godimsg.ConsumerTag("orders"))
+//
+//	err := godimsg.Subscribe(container, nil, func(topic string, handle func(context.Context, *kafka.Message) error) error {
+//		return router.On(topic, handle)
+//	})
+package godimsg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// MessageName is the name, under which the handled message is provided.
+const MessageName = "message"
+
+// MetadataPrefix is prepended to every metadata key to derive the
+// name, under which the metadata value is provided.
+const MetadataPrefix = "message."
+
+// MetadataName returns the name, under which the metadata value
+// of the given key is provided.
+func MetadataName(key string) string {
+	return MetadataPrefix + key
+}
+
+// Handle runs fn within a new scope of the Container, which provides the
+// message and its metadata. The scope is closed after fn returned.
+func Handle(ctx context.Context, c godi.Container, msg any, metadata map[string]any, fn func(ctx context.Context, resolver godi.ResolverFunc) error) error {
+	scoper, ok := godi.AsScoper(c)
+	if !ok {
+		return errors.New("unable to handle message. container doesn't implement godi.Scoper")
+	}
+	scope := scoper.NewScope()
+	defer scope.Close()
+	if err := scope.Provide(MessageName, msg); err != nil {
+		return err
+	}
+	for key, value := range metadata {
+		if err := scope.Provide(MetadataName(key), value); err != nil {
+			return err
+		}
+	}
+	return fn(ctx, scope.ResolverContext(ctx))
+}
+
+// Handler adapts a handler of typed messages to a consumer callback, which
+// handles every message within its own scope. The metadata function extracts
+// the metadata provided to the scope and may be nil.
+func Handler[M any](c godi.Container, metadata func(msg M) map[string]any, handle func(ctx context.Context, msg M, resolver godi.ResolverFunc) error) func(ctx context.Context, msg M) error {
+	return func(ctx context.Context, msg M) error {
+		var meta map[string]any
+		if metadata != nil {
+			meta = metadata(msg)
+		}
+		return Handle(ctx, c, msg, meta, func(ctx context.Context, resolver godi.ResolverFunc) error {
+			return handle(ctx, msg, resolver)
+		})
+	}
+}
+
+// ConsumerTagPrefix is prepended to a topic to derive the tag, which marks
+// dependencies as consumers of the topic.
+const ConsumerTagPrefix = "consumer:"
+
+// ConsumerTag returns the tag, which marks dependencies as consumers of
+// the given topic.
+func ConsumerTag(topic string) string {
+	return ConsumerTagPrefix + topic
+}
+
+// Consumer handles the messages of the topics it is tagged with.
+type Consumer[M any] interface {
+	// Consume handles a single message. The resolver resolves
+	// dependencies within the scope of the message.
+	Consume(ctx context.Context, msg M, resolver godi.ResolverFunc) error
+}
+
+// Subscribe discovers all consumers of the Container and attaches them to
+// their topics through the given subscribe function, ordered by topic and
+// name. Every consumer is resolved for each message within the message's
+// scope, like Handler does, so consumers may depend on the message and its
+// metadata. Consumers are never constructed by Subscribe itself. An error
+// is returned before anything is subscribed, if the declared type of a
+// consumer, see godi.DeclareType, doesn't implement Consumer.
+func Subscribe[M any](c godi.Container, metadata func(msg M) map[string]any, subscribe func(topic string, handle func(ctx context.Context, msg M) error) error) error {
+	type subscription struct {
+		topic string
+		name  string
+		typ   string
+	}
+	introspector, ok := godi.AsIntrospector(c)
+	if !ok {
+		return errors.New("unable to discover consumers. container doesn't implement godi.Introspector")
+	}
+	var subscriptions []subscription
+	for _, name := range introspector.Names() {
+		info, err := introspector.Info(name)
+		if err != nil || info.Kind == godi.KindAlias {
+			continue
+		}
+		for _, tag := range info.Tags {
+			if topic, ok := strings.CutPrefix(tag, ConsumerTagPrefix); ok {
+				subscriptions = append(subscriptions, subscription{topic: topic, name: name, typ: info.Type})
+			}
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if subscriptions[i].topic != subscriptions[j].topic {
+			return subscriptions[i].topic < subscriptions[j].topic
+		}
+		return subscriptions[i].name < subscriptions[j].name
+	})
+	consumers := make(map[string]bool)
+	for _, name := range godi.FindImplementations[Consumer[M]](c) {
+		consumers[name] = true
+	}
+	for _, s := range subscriptions {
+		if s.typ != "" && !consumers[s.name] {
+			return errors.New(fmt.Sprintf("consumer %s of topic %s is declared as %s, which doesn't implement Consumer", s.name, s.topic, s.typ))
+		}
+	}
+	for _, s := range subscriptions {
+		name := s.name
+		handle := Handler(c, metadata, func(ctx context.Context, msg M, resolver godi.ResolverFunc) error {
+			consumer, err := godi.Resolve[Consumer[M]](name, resolver)
+			if err != nil {
+				return err
+			}
+			return consumer.Consume(ctx, msg, resolver)
+		})
+		if err := subscribe(s.topic, handle); err != nil {
+			return fmt.Errorf("unable to subscribe consumer %s to topic %s: %w", name, s.topic, err)
+		}
+	}
+	return nil
+}
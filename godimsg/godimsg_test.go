@@ -0,0 +1,149 @@
+package godimsg
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+type message struct {
+	topic string
+	body  string
+}
+
+type processor struct {
+	topic string
+	msg   *message
+}
+
+func TestHandler(t *testing.T) {
+	container := godi.NewContainer()
+	container.MustBind("processor", func(resolver godi.ResolverFunc) any {
+		return &processor{
+			topic: godi.MustResolve[string](MetadataName("topic"), resolver),
+			msg:   godi.MustResolve[*message](MessageName, resolver),
+		}
+	})
+	container.Lock()
+
+	var scoped godi.ResolverFunc
+	handle := Handler(container, func(msg *message) map[string]any {
+		return map[string]any{"topic": msg.topic}
+	}, func(ctx context.Context, msg *message, resolver godi.ResolverFunc) error {
+		p := godi.MustResolve[*processor]("processor", resolver)
+		if p.topic != msg.topic || p.msg != msg {
+			t.Fatalf("Processor not constructed with message values: %+v", p)
+		}
+		scoped = resolver
+		return nil
+	})
+
+	for _, msg := range []*message{{topic: "orders", body: "1"}, {topic: "payments", body: "2"}} {
+		if err := handle(context.Background(), msg); err != nil {
+			t.Fatalf("Unable to handle message: %s", err)
+		}
+	}
+	if _, err := scoped("processor"); err == nil {
+		t.Fatalf("Scope of handled message not closed")
+	}
+	if _, err := container.Resolver()(MessageName); err == nil {
+		t.Fatalf("Message leaked into container")
+	}
+}
+
+type consumer struct {
+	name     string
+	consumed *[]string
+}
+
+func (c *consumer) Consume(ctx context.Context, msg *message, resolver godi.ResolverFunc) error {
+	*c.consumed = append(*c.consumed, c.name+":"+msg.body)
+	return nil
+}
+
+func TestSubscribe(t *testing.T) {
+	var consumed []string
+	container := godi.NewContainer()
+	for _, name := range []string{"audit", "billing"} {
+		name := name
+		container.MustBind(name, func(resolver godi.ResolverFunc) any {
+			return &consumer{name: name, consumed: &consumed}
+		})
+	}
+	_ = container.Tag("audit", ConsumerTag("orders"), ConsumerTag("payments"))
+	_ = container.Tag("billing", ConsumerTag("orders"))
+	_ = container.Alias("auditor", "audit")
+
+	handlers := make(map[string][]func(context.Context, *message) error)
+	var order []string
+	err := Subscribe(container, nil, func(topic string, handle func(context.Context, *message) error) error {
+		handlers[topic] = append(handlers[topic], handle)
+		order = append(order, topic)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unable to subscribe consumers: %s", err)
+	}
+	if !reflect.DeepEqual(order, []string{"orders", "orders", "payments"}) {
+		t.Fatalf("Unexpected subscriptions %v", order)
+	}
+	for _, topic := range []string{"orders", "payments"} {
+		for _, handle := range handlers[topic] {
+			if err = handle(context.Background(), &message{topic: topic, body: topic}); err != nil {
+				t.Fatalf("Unable to handle message: %s", err)
+			}
+		}
+	}
+	if !reflect.DeepEqual(consumed, []string{"audit:orders", "billing:orders", "audit:payments"}) {
+		t.Fatalf("Unexpected consumed messages %v", consumed)
+	}
+
+	container.MustBind("broken", func(resolver godi.ResolverFunc) any {
+		return "not a consumer"
+	}, godi.DeclareType(reflect.TypeOf("")))
+	_ = container.Tag("broken", ConsumerTag("orders"))
+	if err = Subscribe(container, nil, func(string, func(context.Context, *message) error) error {
+		t.Fatalf("Subscribed despite broken consumer")
+		return nil
+	}); err == nil {
+		t.Fatalf("Subscribed dependency, which is not a consumer")
+	}
+}
+
+type topicConsumer struct {
+	topic    string
+	consumed *[]string
+}
+
+func (c *topicConsumer) Consume(ctx context.Context, msg *message, resolver godi.ResolverFunc) error {
+	*c.consumed = append(*c.consumed, c.topic+":"+msg.body)
+	return nil
+}
+
+func TestSubscribe_Metadata(t *testing.T) {
+	var consumed []string
+	container := godi.NewContainer()
+	container.MustBind("tracing", func(resolver godi.ResolverFunc) any {
+		return &topicConsumer{topic: godi.MustResolve[string](MetadataName("topic"), resolver), consumed: &consumed}
+	}, godi.Scoped(), godi.DeclareType(reflect.TypeOf(&topicConsumer{})))
+	_ = container.Tag("tracing", ConsumerTag("orders"))
+
+	var handle func(context.Context, *message) error
+	err := Subscribe(container, func(msg *message) map[string]any {
+		return map[string]any{"topic": msg.topic}
+	}, func(topic string, h func(context.Context, *message) error) error {
+		handle = h
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unable to subscribe consumer depending on metadata: %s", err)
+	}
+	if err = handle(context.Background(), &message{topic: "orders", body: "1"}); err != nil {
+		t.Fatalf("Unable to handle message: %s", err)
+	}
+	if !reflect.DeepEqual(consumed, []string{"orders:1"}) {
+		t.Fatalf("Unexpected consumed messages %v", consumed)
+	}
+}
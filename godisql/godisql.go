@@ -0,0 +1,104 @@
+// Package godisql implements a unit of work pattern for database/sql on top
+// of godi scopes.
+//
+// The root *sql.DB is bound to the container as Querier. WithTx opens a
+// transaction, creates a godi.Scope providing the *sql.Tx as Querier and
+// runs a function within the scope. Instanced repository bindings resolving
+// their Querier through the container therefore operate on the transaction
+// within the unit of work, and on the root database outside of it.
+//
+//	godisql.MustBindDB(container, db)
+//	container.MustBind("users", func(resolver godi.ResolverFunc) any {
+//		return &UserRepository{db: godisql.MustQuerier(resolver)}
+//	})
+//
+//	err := godisql.WithTx(ctx, container, db, nil, func(ctx context.Context, resolver godi.ResolverFunc) error {
+//		users := godi.MustResolve[*UserRepository]("users", resolver)
+//		return users.Create(ctx, user)
+//	})
+package godisql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// QuerierName is the name of the Querier dependency.
+const QuerierName = "godisql.querier"
+
+// TxName is the name of the *sql.Tx provided to the scope of a unit of work.
+const TxName = "godisql.tx"
+
+// Querier is implemented by *sql.DB and *sql.Tx, allowing repositories
+// to operate on either of them.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// BindDB binds the given database as root Querier to the Container.
+func BindDB(c godi.Container, db *sql.DB) error {
+	return c.Bind(QuerierName, func(resolver godi.ResolverFunc) any {
+		return Querier(db)
+	})
+}
+
+// MustBindDB is like BindDB, but panics on a failed bind.
+func MustBindDB(c godi.Container, db *sql.DB) {
+	if err := BindDB(c, db); err != nil {
+		panic(err.Error())
+	}
+}
+
+// GetQuerier resolves the Querier of the current unit of work, or the root
+// database if resolved outside a unit of work.
+func GetQuerier(resolver godi.ResolverFunc) (Querier, error) {
+	return godi.Resolve[Querier](QuerierName, resolver)
+}
+
+// MustQuerier is like GetQuerier, but panics if the Querier can't be resolved.
+func MustQuerier(resolver godi.ResolverFunc) Querier {
+	return godi.MustResolve[Querier](QuerierName, resolver)
+}
+
+// WithTx runs fn as a unit of work. It begins a transaction on db and
+// provides it to a new scope of the Container under QuerierName and TxName.
+// The transaction is committed, if fn succeeds, and rolled back, if fn
+// returns an error or panics. The scope is closed afterwards.
+func WithTx(ctx context.Context, c godi.Container, db *sql.DB, opts *sql.TxOptions, fn func(ctx context.Context, resolver godi.ResolverFunc) error) (err error) {
+	scoper, ok := godi.AsScoper(c)
+	if !ok {
+		return errors.New("unable to begin unit of work. container doesn't implement godi.Scoper")
+	}
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	scope := scoper.NewScope()
+	defer scope.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	if err = scope.Provide(QuerierName, Querier(tx)); err == nil {
+		err = scope.Provide(TxName, tx)
+	}
+	if err == nil {
+		err = fn(ctx, scope.ResolverContext(ctx))
+	}
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("unable to rollback transaction: %w", rollbackErr))
+		}
+		return err
+	}
+	return tx.Commit()
+}
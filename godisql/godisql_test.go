@@ -0,0 +1,112 @@
+package godisql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+type recordingDriver struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return &recordingTx{driver: c.driver}, nil
+}
+
+type recordingTx struct {
+	driver *recordingDriver
+}
+
+func (t *recordingTx) Commit() error {
+	t.driver.mu.Lock()
+	defer t.driver.mu.Unlock()
+	t.driver.commits++
+	return nil
+}
+
+func (t *recordingTx) Rollback() error {
+	t.driver.mu.Lock()
+	defer t.driver.mu.Unlock()
+	t.driver.rollbacks++
+	return nil
+}
+
+var testDriver = &recordingDriver{}
+
+func init() {
+	sql.Register("godisql-recording", testDriver)
+}
+
+type repository struct {
+	db Querier
+}
+
+func TestWithTx(t *testing.T) {
+	db, err := sql.Open("godisql-recording", "")
+	if err != nil {
+		t.Fatalf("Unable to open database: %s", err)
+	}
+	defer db.Close()
+
+	container := godi.NewContainer()
+	MustBindDB(container, db)
+	container.MustBind("repository", func(resolver godi.ResolverFunc) any {
+		return &repository{db: MustQuerier(resolver)}
+	})
+	container.Lock()
+
+	if repo := godi.MustResolve[*repository]("repository", container.Resolver()); repo.db != Querier(db) {
+		t.Fatalf("Expected root database outside unit of work, got %T", repo.db)
+	}
+
+	var tx *sql.Tx
+	err = WithTx(context.Background(), container, db, nil, func(ctx context.Context, resolver godi.ResolverFunc) error {
+		repo := godi.MustResolve[*repository]("repository", resolver)
+		tx = godi.MustResolve[*sql.Tx](TxName, resolver)
+		if repo.db != Querier(tx) {
+			t.Fatalf("Expected transaction within unit of work, got %T", repo.db)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unit of work failed: %s", err)
+	}
+	if testDriver.commits != 1 || testDriver.rollbacks != 0 {
+		t.Fatalf("Expected one commit, got %d commits and %d rollbacks", testDriver.commits, testDriver.rollbacks)
+	}
+
+	failure := errors.New("failure")
+	err = WithTx(context.Background(), container, db, nil, func(ctx context.Context, resolver godi.ResolverFunc) error {
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected error of unit of work, got %v", err)
+	}
+	if testDriver.commits != 1 || testDriver.rollbacks != 1 {
+		t.Fatalf("Expected one rollback, got %d commits and %d rollbacks", testDriver.commits, testDriver.rollbacks)
+	}
+}
@@ -0,0 +1,51 @@
+// Package goditemplate assembles html/template functions from the
+// bindings of a godi.Container.
+//
+// Template functions are dependencies tagged with FuncTag, which resolve
+// to a function. FuncMap collects them under the names they are bound
+// with, so independent modules can contribute template helpers through
+// the container.
+//
+//	container.MustBindSingleton("upper", func(resolver godi.ResolverFunc) any {
+//		return strings.ToUpper
+//	})
+//	container.Tag("upper", goditemplate.FuncTag)
+//
+//	funcs, err := goditemplate.FuncMap(container)
+//	tmpl := template.Must(template.New("page").Funcs(funcs).Parse(`{{upper .Title}}`))
+package goditemplate
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"html/template"
+	"reflect"
+
+	"github.com/jschaefer-io/godi"
+)
+
+// FuncTag is the tag, which marks dependencies as template functions.
+const FuncTag = "template-func"
+
+// FuncMap resolves all template functions of the Container into
+// a template.FuncMap. An error is returned, if a name is no valid
+// identifier or a dependency can't be resolved to a function.
+func FuncMap(c godi.Container) (template.FuncMap, error) {
+	names := c.Tagged(FuncTag)
+	funcs := make(template.FuncMap, len(names))
+	for _, name := range names {
+		if !token.IsIdentifier(name) {
+			return nil, errors.New(fmt.Sprintf("template function %s is no valid identifier", name))
+		}
+		fn, err := c.Resolver()(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve template function %s: %w", name, err)
+		}
+		if fn == nil || reflect.TypeOf(fn).Kind() != reflect.Func {
+			return nil, errors.New(fmt.Sprintf("template function %s resolved to %T, which is no function", name, fn))
+		}
+		funcs[name] = fn
+	}
+	return funcs, nil
+}
@@ -0,0 +1,48 @@
+package goditemplate
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/jschaefer-io/godi"
+)
+
+func bindFunc(t *testing.T, c godi.Container, name string, fn any) {
+	c.MustBindSingleton(name, func(resolver godi.ResolverFunc) any {
+		return fn
+	})
+	if err := c.Tag(name, FuncTag); err != nil {
+		t.Fatalf("Unable to tag template function %s: %s", name, err)
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	container := godi.NewContainer()
+	bindFunc(t, container, "upper", strings.ToUpper)
+	bindFunc(t, container, "repeat", strings.Repeat)
+
+	funcs, err := FuncMap(container)
+	if err != nil {
+		t.Fatalf("Unable to assemble func map: %s", err)
+	}
+	var out strings.Builder
+	tmpl := template.Must(template.New("page").Funcs(funcs).Parse(`{{repeat (upper .) 2}}`))
+	if err = tmpl.Execute(&out, "go"); err != nil {
+		t.Fatalf("Unable to execute template: %s", err)
+	}
+	if out.String() != "GOGO" {
+		t.Fatalf("Unexpected template output %s", out.String())
+	}
+
+	invalid := godi.NewContainer()
+	bindFunc(t, invalid, "not-an-identifier", strings.ToLower)
+	if _, err = FuncMap(invalid); err == nil {
+		t.Fatalf("Accepted invalid template function name")
+	}
+	invalid = godi.NewContainer()
+	bindFunc(t, invalid, "title", "no function")
+	if _, err = FuncMap(invalid); err == nil {
+		t.Fatalf("Accepted template function, which is no function")
+	}
+}
@@ -0,0 +1,15 @@
+// Command godivet runs the godivet analyzers, checking resolutions of
+// godi dependencies against their bindings and validating the wiring of
+// whole programs. It may be used standalone or as a vet tool:
+//
+//	go vet -vettool=$(which godivet) ./...
+package main
+
+import (
+	"github.com/jschaefer-io/godi/godivet"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(godivet.Analyzer, godivet.WiringAnalyzer)
+}
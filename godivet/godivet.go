@@ -0,0 +1,231 @@
+// Package godivet implements a go/analysis analyzer, which detects
+// mismatches between the dependencies bound to a godi.Container and
+// their resolutions through godi.Resolve or godi.MustResolve.
+//
+// Within a package, the analyzer collects all dependencies bound with a
+// constant name and a function literal as binder, under their qualified
+// name if bound with a constant Qualifier, and derives their type
+// from the returned expressions. Every call of Resolve[T] or MustResolve[T]
+// with a constant name is then checked against those bindings. Calls
+// requesting a type, which the bound value can not be converted to, are
+// reported. If the package binds dependencies itself, resolutions of names
+// unknown to the package are reported as well, except for the names bound
+// by godi.NewContainer, like godi.ClockName.
+//
+// WiringAnalyzer validates the wiring across all packages of a program.
+// Main packages report names resolved but never bound, bindings never
+// resolved and cycles between binders, considering every binding and
+// resolution with a constant name in the packages they import.
+//
+// The analyzer can be run through go vet with the godivet command:
+//
+//	go install github.com/jschaefer-io/godi/godivet/cmd/godivet@latest
+//	go vet -vettool=$(which godivet) ./...
+package godivet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const godiPath = "github.com/jschaefer-io/godi"
+
+// Analyzer reports resolutions of godi dependencies, which are bound
+// with a different type or not bound at all within the same package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "godivet",
+	Doc:      "check godi.Resolve calls against the types of bound dependencies",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var bindMethods = map[string]bool{
+	"Bind":              true,
+	"MustBind":          true,
+	"BindSingleton":     true,
+	"MustBindSingleton": true,
+}
+
+var resolveFuncs = map[string]bool{
+	"Resolve":     true,
+	"MustResolve": true,
+}
+
+type binding struct {
+	// typ is the type of all values returned by the binder,
+	// or nil if the returned types differ.
+	typ types.Type
+}
+
+type resolution struct {
+	call *ast.CallExpr
+	name string
+	typ  types.Type
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	bindings := make(map[string]binding)
+	var resolutions []resolution
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		if name, binder, ok := bindCall(pass, call); ok {
+			if _, exists := bindings[name]; !exists {
+				bindings[name] = binding{typ: binderType(pass, binder)}
+			}
+			return
+		}
+		if r, ok := resolveCall(pass, call); ok {
+			resolutions = append(resolutions, r)
+		}
+	})
+
+	for _, r := range resolutions {
+		b, ok := bindings[r.name]
+		if !ok {
+			if len(bindings) > 0 && !slices.Contains(builtinBindings, r.name) {
+				pass.Reportf(r.call.Pos(), "dependency %q is not bound in this package", r.name)
+			}
+			continue
+		}
+		if b.typ == nil || convertible(b.typ, r.typ) {
+			continue
+		}
+		pass.Reportf(r.call.Pos(), "dependency %q is bound as %s but resolved as %s", r.name, b.typ, r.typ)
+	}
+	return nil, nil
+}
+
+// bindCall reports, whether the call binds a dependency with a constant
+// name and a function literal as binder to a godi.Container.
+func bindCall(pass *analysis.Pass, call *ast.CallExpr) (string, *ast.FuncLit, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !bindMethods[sel.Sel.Name] || len(call.Args) < 2 {
+		return "", nil, false
+	}
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != godiPath {
+		return "", nil, false
+	}
+	name, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return "", nil, false
+	}
+	binder, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
+		return "", nil, false
+	}
+	return qualifiedName(pass, name, call.Args[2:]), binder, true
+}
+
+// qualifiedName returns the name, a binding with the given BindOptions is
+// bound under, which is its QualifiedName, if one of the options is a
+// Qualifier with a constant qualifier.
+func qualifiedName(pass *analysis.Pass, name string, options []ast.Expr) string {
+	for _, option := range options {
+		call, ok := option.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			continue
+		}
+		if fn, ok := godiFunc(pass, call.Fun); !ok || fn.Name() != "Qualifier" {
+			continue
+		}
+		if qualifier, ok := constantString(pass, call.Args[0]); ok {
+			return name + "#" + qualifier
+		}
+	}
+	return name
+}
+
+// resolveCall reports, whether the call resolves a dependency with a
+// constant name through godi.Resolve or godi.MustResolve.
+func resolveCall(pass *analysis.Pass, call *ast.CallExpr) (resolution, bool) {
+	fun := call.Fun
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return resolution{}, false
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != godiPath || !resolveFuncs[fn.Name()] {
+		return resolution{}, false
+	}
+	instance, ok := pass.TypesInfo.Instances[ident]
+	if !ok || instance.TypeArgs.Len() != 1 || len(call.Args) < 1 {
+		return resolution{}, false
+	}
+	name, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return resolution{}, false
+	}
+	return resolution{call: call, name: name, typ: instance.TypeArgs.At(0)}, true
+}
+
+// binderType returns the type of all values returned by the binder,
+// or nil if the returned types differ or can not be determined.
+func binderType(pass *analysis.Pass, binder *ast.FuncLit) types.Type {
+	var typ types.Type
+	consistent := true
+	ast.Inspect(binder.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != 1 {
+				consistent = false
+				return false
+			}
+			t := pass.TypesInfo.TypeOf(n.Results[0])
+			if t == nil || types.IsInterface(t) {
+				consistent = false
+				return false
+			}
+			if basic, ok := t.(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+				t = types.Default(t)
+			}
+			if typ != nil && !types.Identical(typ, t) {
+				consistent = false
+			}
+			typ = t
+		}
+		return true
+	})
+	if !consistent {
+		return nil
+	}
+	return typ
+}
+
+// convertible reports, whether a value of the bound type can be converted
+// to the resolved type by a type assertion.
+func convertible(bound, resolved types.Type) bool {
+	if types.Identical(bound, resolved) {
+		return true
+	}
+	if iface, ok := resolved.Underlying().(*types.Interface); ok {
+		return types.Implements(bound, iface)
+	}
+	return false
+}
+
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
@@ -0,0 +1,15 @@
+package godivet
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestWiringAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), WiringAnalyzer, "wiring/...")
+}
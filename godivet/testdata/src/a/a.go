@@ -0,0 +1,55 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/jschaefer-io/godi"
+)
+
+type Logger interface {
+	Log(msg string)
+}
+
+type stdLogger struct{}
+
+func (stdLogger) Log(msg string) {
+	fmt.Println(msg)
+}
+
+const portName = "port"
+
+func wire() {
+	container := godi.NewContainer()
+	container.MustBind(portName, func(resolver godi.ResolverFunc) any {
+		return 8080
+	})
+	container.MustBindSingleton("logger", func(resolver godi.ResolverFunc) any {
+		return &stdLogger{}
+	})
+	container.MustBindSingleton("clock", func(resolver godi.ResolverFunc) any {
+		return "utc"
+	}, godi.Eager())
+	container.MustBind("db", func(resolver godi.ResolverFunc) any {
+		return 1
+	}, godi.Qualifier("replica"))
+	container.MustBind("mixed", func(resolver godi.ResolverFunc) any {
+		if true {
+			return 1
+		}
+		return "one"
+	})
+
+	resolver := container.Resolver()
+	_ = godi.MustResolve[int]("port", resolver)
+	_ = godi.MustResolve[int64](portName, resolver) // want `dependency "port" is bound as int but resolved as int64`
+	_ = godi.MustResolve[Logger]("logger", resolver)
+	_ = godi.MustResolve[*stdLogger]("logger", resolver)
+	_, _ = godi.Resolve[fmt.Stringer]("logger", resolver) // want `dependency "logger" is bound as \*a.stdLogger but resolved as fmt.Stringer`
+	_ = godi.MustResolve[string]("mixed", resolver)
+	_ = godi.MustResolve[string]("clock", resolver)
+	_ = godi.MustResolve[int]("clock", resolver) // want `dependency "clock" is bound as string but resolved as int`
+	_ = godi.MustResolve[int]("db#replica", resolver)
+	_ = godi.MustResolve[string]("db#replica", resolver) // want `dependency "db#replica" is bound as int but resolved as string`
+	_ = godi.MustResolve[any]("godi.clock", resolver)
+	_ = godi.MustResolve[int]("missing", resolver) // want `dependency "missing" is not bound in this package`
+}
@@ -0,0 +1,60 @@
+package godi
+
+type ResolverFunc = func(string) (any, error)
+
+type BinderFunc = func(resolver ResolverFunc) any
+
+type bindOptions struct{}
+
+type BindOption func(o *bindOptions)
+
+func Eager() BindOption {
+	return nil
+}
+
+func Qualifier(qualifier string) BindOption {
+	return nil
+}
+
+func Tags(tags ...string) BindOption {
+	return nil
+}
+
+type Container interface {
+	Bind(name string, binder BinderFunc, options ...BindOption) error
+	MustBind(name string, binder BinderFunc, options ...BindOption)
+	BindSingleton(name string, binder BinderFunc, options ...BindOption) error
+	MustBindSingleton(name string, binder BinderFunc, options ...BindOption)
+	Alias(name, target string) error
+	Tag(name string, tags ...string) error
+	Tagged(tag string) []string
+	Resolver() ResolverFunc
+}
+
+func NewContainer() Container {
+	return nil
+}
+
+func Resolve[T any](name string, resolver ResolverFunc) (T, error) {
+	var v T
+	return v, nil
+}
+
+func MustResolve[T any](name string, resolver ResolverFunc) T {
+	var v T
+	return v
+}
+
+func ResolveQualified[T any](name, qualifier string, resolver ResolverFunc) (T, error) {
+	var v T
+	return v, nil
+}
+
+func ResolveGroup[T any](c Container, tag string) ([]T, error) {
+	return nil, nil
+}
+
+func ResolveTagged[T any](c Container, tag string) (T, error) {
+	var v T
+	return v, nil
+}
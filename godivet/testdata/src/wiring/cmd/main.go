@@ -0,0 +1,21 @@
+package main // want package:"wiring\\(1 bindings, 4 resolutions\\)" `dependency "cache" resolved at wiring/cmd/main.go:16 is never bound` `dependency "unused" bound at wiring/cmd/main.go:12 is never resolved` `dependency "orders" bound at wiring/lib/lib.go:32 is never resolved` `dependency cycle a -> b -> a`
+
+import (
+	"wiring/lib"
+
+	"github.com/jschaefer-io/godi"
+)
+
+func main() {
+	container := godi.NewContainer()
+	lib.Wire(container)
+	container.MustBind("unused", func(resolver godi.ResolverFunc) any {
+		return 1
+	})
+	_ = godi.MustResolve[string]("store", container.Resolver())
+	_, _ = container.Resolver()("cache")
+	_, _ = godi.ResolveQualified[string]("db", "replica", container.Resolver())
+	_, _ = godi.ResolveGroup[string](container, "routes")
+	_ = container.Tagged("plugins")
+	_, _ = container.Resolver()("godi.clock")
+}
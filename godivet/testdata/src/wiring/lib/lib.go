@@ -0,0 +1,35 @@
+package lib // want package:"wiring\\(9 bindings, 4 resolutions\\)"
+
+import (
+	"github.com/jschaefer-io/godi"
+)
+
+func Wire(container godi.Container) {
+	container.MustBind("db", func(resolver godi.ResolverFunc) any {
+		return "db"
+	})
+	container.MustBind("repository", func(resolver godi.ResolverFunc) any {
+		return godi.MustResolve[string]("db", resolver)
+	})
+	container.MustBind("a", func(resolver godi.ResolverFunc) any {
+		_, _ = resolver("b")
+		return "a"
+	})
+	container.MustBind("b", func(resolver godi.ResolverFunc) any {
+		return godi.MustResolve[string]("a", resolver)
+	})
+	_ = container.Alias("store", "repository")
+	container.MustBind("db", func(resolver godi.ResolverFunc) any {
+		return "replica"
+	}, godi.Qualifier("replica"))
+	container.MustBind("users", func(resolver godi.ResolverFunc) any {
+		return "users"
+	}, godi.Tags("routes"))
+	container.MustBind("metrics", func(resolver godi.ResolverFunc) any {
+		return "metrics"
+	})
+	_ = container.Tag("metrics", "plugins")
+	container.MustBind("orders", func(resolver godi.ResolverFunc) any {
+		return "orders"
+	}, godi.Tags("jobs"))
+}
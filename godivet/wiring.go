@@ -0,0 +1,462 @@
+package godivet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// WiringAnalyzer validates the wiring of a whole program. Every package
+// exports the names it binds and resolves with constant names as a fact,
+// including which names the binders of its bindings resolve. Bindings with
+// a constant Qualifier are recorded under their qualified name. Main
+// packages combine the facts of all packages they import and report
+// resolutions of names, which are never bound, bindings, which are never
+// resolved, and cycles between binders. The names bound by NewContainer
+// itself, like godi.ClockName, are always bound. Bindings carrying a constant tag,
+// which is resolved through Tagged, ResolveGroup or ResolveTagged, count
+// as resolved.
+var WiringAnalyzer = &analysis.Analyzer{
+	Name:      "godiwiring",
+	Doc:       "check the godi wiring of a program for unbound names, unused bindings and cycles",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       runWiring,
+	FactTypes: []analysis.Fact{new(wiringFact)},
+}
+
+// wiringBindMethods maps the functions binding dependencies to the
+// index of their binder argument, or -1 if they bind a value.
+var wiringBindMethods = map[string]int{
+	"Bind":               1,
+	"MustBind":           1,
+	"BindCtx":            1,
+	"BindSingleton":      1,
+	"MustBindSingleton":  1,
+	"BindIfAbsent":       1,
+	"GetOrBindSingleton": 1,
+	"BindWhen":           -1,
+	"BindCanary":         -1,
+	"BindVersion":        -1,
+	"BindSecret":         -1,
+	"BindPool":           2,
+}
+
+// builtinBindings lists the names bound by every Container created
+// through godi.NewContainer.
+var builtinBindings = []string{"godi.clock"}
+
+// wiringTagFuncs maps the functions resolving dependencies by their tag
+// to the index of their tag argument.
+var wiringTagFuncs = map[string]int{
+	"Tagged":        0,
+	"ResolveGroup":  1,
+	"ResolveTagged": 1,
+}
+
+var wiringResolveFuncs = map[string]bool{
+	"Resolve":           true,
+	"MustResolve":       true,
+	"ResolveDefault":    true,
+	"FactoryOf":         true,
+	"ResolveQualified":  true,
+	"ResolveAssignable": true,
+}
+
+// Site is a name bound or resolved at a source position.
+type Site struct {
+	Name     string
+	Position string
+}
+
+// Edge records, that the binder of From resolves To.
+type Edge struct {
+	From string
+	To   string
+}
+
+// Tag records, that the binding Name carries the tag Tag.
+type Tag struct {
+	Name string
+	Tag  string
+}
+
+// wiringFact describes the constant names a package binds and resolves.
+// TagResolutions lists the tags, which are resolved by their name.
+type wiringFact struct {
+	Bindings       []Site
+	Resolutions    []Site
+	Edges          []Edge
+	Tags           []Tag
+	TagResolutions []Site
+}
+
+func (*wiringFact) AFact() {}
+
+func (f *wiringFact) String() string {
+	return fmt.Sprintf("wiring(%d bindings, %d resolutions)", len(f.Bindings), len(f.Resolutions))
+}
+
+func runWiring(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	fact := new(wiringFact)
+	binders := make(map[*ast.FuncLit]string)
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		if name, target, ok := aliasCall(pass, call); ok {
+			site := position(pass, call.Pos())
+			fact.Bindings = append(fact.Bindings, Site{Name: name, Position: site})
+			fact.Resolutions = append(fact.Resolutions, Site{Name: target, Position: site})
+			fact.Edges = append(fact.Edges, Edge{From: name, To: target})
+			return
+		}
+		if name, tags, ok := tagCall(pass, call); ok {
+			for _, tag := range tags {
+				fact.Tags = append(fact.Tags, Tag{Name: name, Tag: tag})
+			}
+			return
+		}
+		if tag, ok := tagResolveCall(pass, call); ok {
+			fact.TagResolutions = append(fact.TagResolutions, Site{Name: tag, Position: position(pass, call.Pos())})
+			return
+		}
+		name, binder, ok := wiringBindCall(pass, call)
+		if !ok {
+			return
+		}
+		fact.Bindings = append(fact.Bindings, Site{Name: name, Position: position(pass, call.Pos())})
+		for _, tag := range optionTags(pass, call.Args[1:]) {
+			fact.Tags = append(fact.Tags, Tag{Name: name, Tag: tag})
+		}
+		if binder != nil {
+			binders[binder] = name
+		}
+	})
+	insp.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := node.(*ast.CallExpr)
+		name, ok := wiringResolveCall(pass, call)
+		if !ok {
+			return true
+		}
+		fact.Resolutions = append(fact.Resolutions, Site{Name: name, Position: position(pass, call.Pos())})
+		if from, bound := enclosingBinder(stack, binders); bound {
+			fact.Edges = append(fact.Edges, Edge{From: from, To: name})
+		}
+		return true
+	})
+	if len(fact.Bindings) > 0 || len(fact.Resolutions) > 0 || len(fact.Tags) > 0 || len(fact.TagResolutions) > 0 {
+		pass.ExportPackageFact(fact)
+	}
+
+	if pass.Pkg.Name() != "main" || len(pass.Files) == 0 {
+		return nil, nil
+	}
+	program := &wiringFact{
+		Bindings:       append([]Site(nil), fact.Bindings...),
+		Resolutions:    append([]Site(nil), fact.Resolutions...),
+		Edges:          append([]Edge(nil), fact.Edges...),
+		Tags:           append([]Tag(nil), fact.Tags...),
+		TagResolutions: append([]Site(nil), fact.TagResolutions...),
+	}
+	for _, imported := range pass.AllPackageFacts() {
+		if f, ok := imported.Fact.(*wiringFact); ok && imported.Package != pass.Pkg {
+			program.Bindings = append(program.Bindings, f.Bindings...)
+			program.Resolutions = append(program.Resolutions, f.Resolutions...)
+			program.Edges = append(program.Edges, f.Edges...)
+			program.Tags = append(program.Tags, f.Tags...)
+			program.TagResolutions = append(program.TagResolutions, f.TagResolutions...)
+		}
+	}
+	reportWiring(pass, pass.Files[0].Package, program)
+	return nil, nil
+}
+
+// reportWiring reports all wiring errors of the program at the given
+// position.
+func reportWiring(pass *analysis.Pass, pos token.Pos, program *wiringFact) {
+	bound := make(map[string]bool, len(program.Bindings)+len(builtinBindings))
+	for _, name := range builtinBindings {
+		bound[name] = true
+	}
+	for _, site := range program.Bindings {
+		bound[site.Name] = true
+	}
+	resolved := make(map[string]bool, len(program.Resolutions))
+	for _, site := range program.Resolutions {
+		resolved[site.Name] = true
+		if !bound[site.Name] {
+			pass.Reportf(pos, "dependency %q resolved at %s is never bound", site.Name, site.Position)
+		}
+	}
+	resolvedTags := make(map[string]bool, len(program.TagResolutions))
+	for _, site := range program.TagResolutions {
+		resolvedTags[site.Name] = true
+	}
+	for _, tag := range program.Tags {
+		if resolvedTags[tag.Tag] {
+			resolved[tag.Name] = true
+		}
+	}
+	reported := make(map[string]bool)
+	for _, site := range program.Bindings {
+		if !resolved[site.Name] && !reported[site.Name] {
+			reported[site.Name] = true
+			pass.Reportf(pos, "dependency %q bound at %s is never resolved", site.Name, site.Position)
+		}
+	}
+	for _, cycle := range cycles(program.Edges) {
+		pass.Reportf(pos, "dependency cycle %s", strings.Join(cycle, " -> "))
+	}
+}
+
+// cycles returns every cycle of the graph once, starting at its
+// lexicographically smallest name.
+func cycles(edges []Edge) [][]string {
+	graph := make(map[string][]string)
+	for _, e := range edges {
+		graph[e.From] = append(graph[e.From], e.To)
+	}
+	names := make([]string, 0, len(graph))
+	for name, targets := range graph {
+		names = append(names, name)
+		sort.Strings(targets)
+	}
+	sort.Strings(names)
+
+	var found [][]string
+	seen := make(map[string]bool)
+	state := make(map[string]int)
+	var path []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = 1
+		path = append(path, name)
+		for _, target := range graph[name] {
+			switch state[target] {
+			case 0:
+				visit(target)
+			case 1:
+				start := 0
+				for path[start] != target {
+					start++
+				}
+				cycle := rotate(path[start:])
+				key := strings.Join(cycle, "\x00")
+				if !seen[key] {
+					seen[key] = true
+					found = append(found, append(cycle, cycle[0]))
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = 2
+	}
+	for _, name := range names {
+		if state[name] == 0 {
+			visit(name)
+		}
+	}
+	return found
+}
+
+// rotate returns a copy of the cycle starting at its smallest name.
+func rotate(cycle []string) []string {
+	smallest := 0
+	for i, name := range cycle {
+		if name < cycle[smallest] {
+			smallest = i
+		}
+	}
+	return append(append([]string(nil), cycle[smallest:]...), cycle[:smallest]...)
+}
+
+// wiringBindCall reports, whether the call binds a dependency with a
+// constant name, returning its binder if it is a function literal.
+func wiringBindCall(pass *analysis.Pass, call *ast.CallExpr) (string, *ast.FuncLit, bool) {
+	fn, ok := godiFunc(pass, call.Fun)
+	if !ok {
+		return "", nil, false
+	}
+	index, ok := wiringBindMethods[fn.Name()]
+	if !ok || len(call.Args) == 0 {
+		return "", nil, false
+	}
+	name, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return "", nil, false
+	}
+	name = qualifiedName(pass, name, call.Args[1:])
+	if index < 0 || index >= len(call.Args) {
+		return name, nil, true
+	}
+	binder, _ := call.Args[index].(*ast.FuncLit)
+	return name, binder, true
+}
+
+// optionTags returns the constant tags attached by the Tags options
+// among the given BindOptions.
+func optionTags(pass *analysis.Pass, options []ast.Expr) []string {
+	var tags []string
+	for _, option := range options {
+		call, ok := option.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if fn, ok := godiFunc(pass, call.Fun); !ok || fn.Name() != "Tags" {
+			continue
+		}
+		tags = append(tags, constantStrings(pass, call.Args)...)
+	}
+	return tags
+}
+
+// tagCall reports, whether the call tags the dependency with a constant
+// name through Container.Tag, returning its constant tags.
+func tagCall(pass *analysis.Pass, call *ast.CallExpr) (string, []string, bool) {
+	fn, ok := godiFunc(pass, call.Fun)
+	if !ok || fn.Name() != "Tag" || len(call.Args) == 0 {
+		return "", nil, false
+	}
+	name, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return "", nil, false
+	}
+	return name, constantStrings(pass, call.Args[1:]), true
+}
+
+// tagResolveCall returns the constant tag resolved by the call.
+func tagResolveCall(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	fn, ok := godiFunc(pass, call.Fun)
+	if !ok {
+		return "", false
+	}
+	index, ok := wiringTagFuncs[fn.Name()]
+	if !ok || index >= len(call.Args) {
+		return "", false
+	}
+	return constantString(pass, call.Args[index])
+}
+
+// constantStrings returns the values of all constant string expressions.
+func constantStrings(pass *analysis.Pass, exprs []ast.Expr) []string {
+	var values []string
+	for _, expr := range exprs {
+		if value, ok := constantString(pass, expr); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// aliasCall reports, whether the call aliases a target with a constant
+// name under a constant name.
+func aliasCall(pass *analysis.Pass, call *ast.CallExpr) (string, string, bool) {
+	fn, ok := godiFunc(pass, call.Fun)
+	if !ok || fn.Name() != "Alias" || len(call.Args) != 2 {
+		return "", "", false
+	}
+	name, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return "", "", false
+	}
+	target, ok := constantString(pass, call.Args[1])
+	return name, target, ok
+}
+
+// wiringResolveCall returns the constant name resolved by the call,
+// either through a godi helper function or a direct call of a
+// ResolverFunc.
+func wiringResolveCall(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	if len(call.Args) == 0 {
+		return "", false
+	}
+	fn, ok := godiFunc(pass, call.Fun)
+	if !ok {
+		if !isResolverFunc(pass.TypesInfo.TypeOf(call.Fun)) {
+			return "", false
+		}
+		return constantString(pass, call.Args[0])
+	}
+	if !wiringResolveFuncs[fn.Name()] {
+		return "", false
+	}
+	name, ok := constantString(pass, call.Args[0])
+	if fn.Name() == "ResolveQualified" && len(call.Args) > 1 {
+		qualifier, qualified := constantString(pass, call.Args[1])
+		return name + "#" + qualifier, ok && qualified
+	}
+	return name, ok
+}
+
+// godiFunc returns the godi function or Container method called through
+// the given expression.
+func godiFunc(pass *analysis.Pass, fun ast.Expr) (*types.Func, bool) {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil, false
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != godiPath {
+		return nil, false
+	}
+	return fn, true
+}
+
+// isResolverFunc reports, whether the type is the signature of a
+// godi.ResolverFunc.
+func isResolverFunc(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	sig, ok := t.Underlying().(*types.Signature)
+	if !ok || sig.Params().Len() != 1 || sig.Results().Len() != 2 {
+		return false
+	}
+	if basic, ok := sig.Params().At(0).Type().(*types.Basic); !ok || basic.Kind() != types.String {
+		return false
+	}
+	if iface, ok := sig.Results().At(0).Type().Underlying().(*types.Interface); !ok || !iface.Empty() {
+		return false
+	}
+	return types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// enclosingBinder returns the name of the binding, whose binder contains
+// the innermost node of the stack.
+func enclosingBinder(stack []ast.Node, binders map[*ast.FuncLit]string) (string, bool) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if lit, ok := stack[i].(*ast.FuncLit); ok {
+			if name, ok := binders[lit]; ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func position(pass *analysis.Pass, pos token.Pos) string {
+	p := pass.Fset.Position(pos)
+	return fmt.Sprintf("%s/%s:%d", pass.Pkg.Path(), filepath.Base(p.Filename), p.Line)
+}
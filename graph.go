@@ -0,0 +1,193 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// edge is a dependency between two bindings recorded by the Container.
+type edge struct {
+	from string
+	to   string
+}
+
+type dependentKey struct{}
+
+// dependent is the binding constructed within a context and the Module
+// it was installed by, linked to the bindings whose construction resolved
+// it. Declared dependents resolve the dependencies declared through
+// DependsOn, which aren't recorded.
+type dependent struct {
+	name     string
+	module   string
+	declared bool
+	parent   *dependent
+}
+
+// dependentFrom returns the binding constructed within the given context,
+// if any.
+func dependentFrom(ctx context.Context) *dependent {
+	dep, _ := ctx.Value(dependentKey{}).(*dependent)
+	return dep
+}
+
+// chain returns the names of all dependents, outermost first.
+func (dep *dependent) chain() []string {
+	var names []string
+	for ; dep != nil; dep = dep.parent {
+		names = append(names, dep.name)
+	}
+	slices.Reverse(names)
+	return names
+}
+
+// recordEdge records, that the binding constructed within the given
+// context resolved the named binding.
+func (d *defaultContainer) recordEdge(ctx context.Context, name string) {
+	from := dependentFrom(ctx)
+	if from == nil || from.declared {
+		return
+	}
+	e := edge{from: from.name, to: name}
+	if _, ok := d.edges.Load(e); !ok {
+		d.edges.Store(e, struct{}{})
+	}
+}
+
+// dependencies returns the sorted names of all bindings, the named
+// binding was recorded to resolve.
+func (d *defaultContainer) dependencies(name string) []string {
+	var names []string
+	d.edges.Range(func(key, _ any) bool {
+		if e := key.(edge); e.from == name {
+			names = append(names, e.to)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+func (d *defaultContainer) Close(ctx context.Context) error {
+	_, err := d.closeSingletons(ctx)
+	return errors.Join(err, d.closeMounts(ctx))
+}
+
+// closeSingletons discards all constructed singletons, which are not
+// shared, and closes them in the order of closeOrder. It returns the
+// sorted names of the discarded singletons.
+func (d *defaultContainer) closeSingletons(ctx context.Context) ([]string, error) {
+	d.mu.RLock()
+	built := make(map[string]*instance)
+	var names []string
+	d.table.eachService(func(name string, b *binding) {
+		if !b.singleton || b.shared {
+			return
+		}
+		if inst, ok := d.instances.LoadAndDelete(b); ok && inst.(*instance).built.Load() {
+			built[name] = inst.(*instance)
+			names = append(names, name)
+		}
+	})
+	d.generation.Add(1)
+	d.mu.RUnlock()
+	d.demoteAll()
+	sort.Strings(names)
+
+	var closers []string
+	for _, name := range d.closeOrder(names) {
+		if _, ok := built[name].value.(io.Closer); ok {
+			closers = append(closers, name)
+		}
+	}
+	var errs []error
+	for i, name := range closers {
+		finished, err := closeWithin(ctx, built[name].value.(io.Closer))
+		if !finished {
+			errs = append(errs, &ShutdownError{Pending: closers[i:], Cause: ctx.Err()})
+			break
+		}
+		d.releaseInstance(built[name].value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to close %s service: %w", name, err))
+		}
+	}
+	return names, errors.Join(errs...)
+}
+
+// closeWithin closes the closer and reports, whether it finished before
+// the given context was done. Closers still running, once the context is
+// done, are left behind.
+func closeWithin(ctx context.Context, closer io.Closer) (bool, error) {
+	if ctx.Err() != nil {
+		return false, nil
+	}
+	if ctx.Done() == nil {
+		return true, closer.Close()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- closer.Close()
+	}()
+	select {
+	case err := <-done:
+		return true, err
+	case <-ctx.Done():
+		return false, nil
+	}
+}
+
+// ShutdownError is returned by Closer.Close, if its context is done
+// before all singletons were closed. Pending names the services, whose
+// Close had not finished, in the order they were to be closed. The first
+// one is the service blocking the shutdown, unless the context was done
+// before Close was called.
+type ShutdownError struct {
+	Pending []string
+	Cause   error
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("shutdown interrupted: %s. pending services: %s", e.Cause, strings.Join(e.Pending, ", "))
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Cause
+}
+
+// closeOrder orders the given names, so every binding comes before all
+// bindings it was recorded to resolve.
+func (d *defaultContainer) closeOrder(names []string) []string {
+	visited := make(map[string]bool, len(names))
+	order := make([]string, 0, len(names))
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dependency := range d.dependencies(name) {
+			visit(dependency)
+		}
+		order = append(order, name)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	included := make(map[string]bool, len(names))
+	for _, name := range names {
+		included[name] = true
+	}
+	closing := make([]string, 0, len(names))
+	for i := len(order) - 1; i >= 0; i-- {
+		if included[order[i]] {
+			closing = append(closing, order[i])
+		}
+	}
+	return closing
+}
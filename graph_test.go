@@ -0,0 +1,140 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+type orderedCloser struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (c *orderedCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestDefaultContainer_Close(t *testing.T) {
+	var closed []string
+	closer := func(name string, err error) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			return &orderedCloser{name: name, closed: &closed, err: err}
+		}
+	}
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", closer("db", nil))
+	container.MustBind("repository", func(resolver ResolverFunc) any {
+		return MustResolve[*orderedCloser]("db", resolver)
+	})
+	container.MustBindSingleton("cache", closer("cache", errors.New("cache failed")))
+	container.MustBindSingleton("api", func(resolver ResolverFunc) any {
+		MustResolve[*orderedCloser]("repository", resolver)
+		MustResolve[*orderedCloser]("cache", resolver)
+		return &orderedCloser{name: "api", closed: &closed}
+	})
+	container.MustBindSingleton("unused", closer("unused", nil))
+	MustResolve[*orderedCloser]("api", container.Resolver())
+
+	if info, _ := container.Info("api"); !reflect.DeepEqual(info.Resolves, []string{"cache", "repository"}) {
+		t.Fatalf("Unexpected recorded dependencies %v", info.Resolves)
+	}
+	if info, _ := container.Info("repository"); !reflect.DeepEqual(info.Resolves, []string{"db"}) {
+		t.Fatalf("Unexpected recorded dependencies %v", info.Resolves)
+	}
+
+	err := container.Close(context.Background())
+	if err == nil {
+		t.Fatalf("Close did not report failed closer")
+	}
+	if expected := []string{"api", "db", "cache"}; !reflect.DeepEqual(closed, expected) {
+		t.Fatalf("Unexpected close order %v, expected %v", closed, expected)
+	}
+
+	closed = nil
+	if err = container.Close(context.Background()); err != nil || len(closed) != 0 {
+		t.Fatalf("Closed instances twice: %v", closed)
+	}
+}
+
+type blockingCloser struct {
+	release chan struct{}
+}
+
+func (c *blockingCloser) Close() error {
+	<-c.release
+	return nil
+}
+
+func TestDefaultContainer_Close_Deadline(t *testing.T) {
+	var closed []string
+	blocking := &blockingCloser{release: make(chan struct{})}
+	defer close(blocking.release)
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return &orderedCloser{name: "db", closed: &closed}
+	})
+	container.MustBindSingleton("queue", func(resolver ResolverFunc) any {
+		MustResolve[*orderedCloser]("db", resolver)
+		return blocking
+	})
+	container.MustBindSingleton("api", func(resolver ResolverFunc) any {
+		MustResolve[*blockingCloser]("queue", resolver)
+		return &orderedCloser{name: "api", closed: &closed}
+	})
+	MustResolve[*orderedCloser]("api", container.Resolver())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := container.Close(ctx)
+	var shutdown *ShutdownError
+	if !errors.As(err, &shutdown) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Blocked shutdown not reported: %v", err)
+	}
+	if !reflect.DeepEqual(shutdown.Pending, []string{"queue", "db"}) {
+		t.Fatalf("Unexpected pending services %v", shutdown.Pending)
+	}
+	if !reflect.DeepEqual(closed, []string{"api"}) {
+		t.Fatalf("Unexpected closed services %v", closed)
+	}
+}
+
+type cachedObject struct {
+	data [1 << 10]byte
+}
+
+func TestDefaultContainer_Close_ReleasesSingletons(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("cache", func(resolver ResolverFunc) any {
+		return &cachedObject{}
+	})
+	collected := make(chan struct{})
+	func() {
+		cache := MustResolve[*cachedObject]("cache", container.Resolver())
+		MustResolve[*cachedObject]("cache", container.Resolver())
+		runtime.SetFinalizer(cache, func(*cachedObject) {
+			close(collected)
+		})
+	}()
+
+	if err := container.Close(context.Background()); err != nil {
+		t.Fatalf("Unable to close container: %s", err)
+	}
+	deadline := time.After(time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-collected:
+			runtime.KeepAlive(container)
+			return
+		case <-deadline:
+			t.Fatalf("Singleton still referenced after close")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package godi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ResolveGroup resolves all dependencies tagged with the given tag and
+// converts them to the given type. Members are ordered by their Priority,
+// highest first, and by their names among equal priorities, so middleware
+// chains and pipeline stages are resolved in a defined order. Private
+// bindings of Modules are never members.
+func ResolveGroup[T any](c Container, tag string) ([]T, error) {
+	tagged := c.Tagged(tag)
+	names := make([]string, 0, len(tagged))
+	priorities := make(map[string]int, len(tagged))
+	introspector, inspectable := AsIntrospector(c)
+	for _, name := range tagged {
+		if inspectable {
+			info, err := introspector.Info(name)
+			if err == nil && info.Private {
+				continue
+			}
+			priorities[name] = info.Priority
+		}
+		names = append(names, name)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return priorities[names[i]] > priorities[names[j]]
+	})
+	members := make([]T, 0, len(names))
+	for _, name := range names {
+		member, err := Resolve[T](name, c.Resolver())
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve member %s of group %s: %w", name, tag, err)
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
@@ -0,0 +1,56 @@
+package godi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveGroup(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	stage := func(name string) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			return name
+		}
+	}
+	container.MustBind("logging", stage("logging"), Tags("middleware"), Priority(100))
+	container.MustBind("auth", stage("auth"), Tags("middleware"), Priority(50))
+	container.MustBind("compression", stage("compression"), Tags("middleware"))
+	container.MustBind("cors", stage("cors"), Tags("middleware"))
+	container.MustBind("metrics", stage("metrics"), Tags("middleware"), Priority(-10))
+	container.MustBind("unrelated", stage("unrelated"))
+
+	chain, err := ResolveGroup[string](container, "middleware")
+	if err != nil {
+		t.Fatalf("Unable to resolve group: %s", err)
+	}
+	if expected := []string{"logging", "auth", "compression", "cors", "metrics"}; !reflect.DeepEqual(chain, expected) {
+		t.Fatalf("Unexpected group order %v, expected %v", chain, expected)
+	}
+	if info, _ := container.Info("auth"); info.Priority != 50 {
+		t.Fatalf("Priority not reported by info: %d", info.Priority)
+	}
+	if _, err = ResolveGroup[int](container, "middleware"); err == nil {
+		t.Fatalf("Resolved group members of mismatching type")
+	}
+	if empty, err := ResolveGroup[string](container, "missing"); err != nil || len(empty) != 0 {
+		t.Fatalf("Unexpected result for empty group %v: %v", empty, err)
+	}
+}
+
+func TestResolveGroup_Private(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	stage := func(resolver ResolverFunc) any {
+		return "stage"
+	}
+	module := NewModule("auth", NewProviderSet().
+		Bind("auth", stage, Tags("middleware")).
+		Bind("session", stage, Tags("middleware"))).
+		Private("session")
+	if err := container.Install(module); err != nil {
+		t.Fatalf("Unable to install module: %s", err)
+	}
+	chain, err := ResolveGroup[string](container, "middleware")
+	if err != nil || len(chain) != 1 {
+		t.Fatalf("Private member not skipped %v: %v", chain, err)
+	}
+}
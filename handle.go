@@ -0,0 +1,118 @@
+package godi
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Handle is a pre-resolved reference to a dependency of a Container,
+// created by Handler.Handle. It remembers the binding its name resolves
+// to, and the value of singletons once they are built, so hot paths
+// resolving the same dependency repeatedly skip the lookups by name.
+// Handles stay valid, when the bindings of the Container change, and
+// follow the changed bindings.
+type Handle interface {
+	// Name returns the name the Handle was created for.
+	Name() string
+	// Resolve resolves the dependency like the ResolverFunc of the
+	// Container does.
+	Resolve() (any, error)
+}
+
+// ResolveTyped resolves the dependency of the Handle and converts it to
+// the given type, like Resolve does.
+func ResolveTyped[T any](h Handle) (T, error) {
+	t, err := h.Resolve()
+	if err != nil {
+		var res T
+		return res, err
+	}
+	v, ok := t.(T)
+	if !ok {
+		return v, mismatch[T](h.Name(), t)
+	}
+	return v, nil
+}
+
+type handle struct {
+	container *defaultContainer
+	name      string
+	state     atomic.Pointer[handleState]
+}
+
+// handleState is the binding a Handle resolved to within one generation
+// of the bindings.
+type handleState struct {
+	generation uint64
+	name       string
+	b          *binding
+	stats      *bindingStats
+	built      bool
+	value      any
+}
+
+func (d *defaultContainer) Handle(name string) (Handle, error) {
+	h := &handle{container: d, name: name}
+	if _, ok := h.current(); !ok {
+		return nil, &notFoundError{name: name}
+	}
+	return h, nil
+}
+
+func (h *handle) Name() string {
+	return h.name
+}
+
+func (h *handle) Resolve() (any, error) {
+	d := h.container
+	ctx := context.Background()
+	state, ok := h.current()
+	if !ok {
+		value, err := d.resolve(ctx, h.name)
+		return value, d.formatError(ctx, h.name, err)
+	}
+	if state.built {
+		if state.b.deprecation != nil {
+			state.b.deprecation.warn(d.log(), state.name)
+		}
+		value, err := d.served(ctx, state.name, state.value, state.stats, time.Now())
+		return value, d.formatError(ctx, h.name, err)
+	}
+	value, err := d.lookupBinding(ctx, state.generation, state.name, state.b, state.stats)
+	if err != nil {
+		return nil, d.formatError(ctx, h.name, err)
+	}
+	if state.b.singleton && !state.b.scoped {
+		next := *state
+		next.built = true
+		next.value = value
+		h.state.CompareAndSwap(state, &next)
+	}
+	return value, nil
+}
+
+// current returns the state of the Handle for the current generation of
+// the bindings, looking the binding up again if they changed.
+func (h *handle) current() (*handleState, bool) {
+	d := h.container
+	generation := d.generation.Load()
+	if state := h.state.Load(); state != nil && state.generation == generation {
+		return state, true
+	}
+	d.mu.RLock()
+	chain := d.aliasChain(h.name)
+	b, ok := d.table.service(chain[len(chain)-1])
+	d.mu.RUnlock()
+	if !ok || b.private {
+		return nil, false
+	}
+	state := &handleState{
+		generation: generation,
+		name:       chain[len(chain)-1],
+		b:          b,
+		stats:      d.statsOf(chain[len(chain)-1]),
+	}
+	h.state.Store(state)
+	return state, true
+}
@@ -0,0 +1,61 @@
+package godi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultContainer_Handle(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var built int
+	container.MustBindSingleton("counter", func(resolver ResolverFunc) any {
+		built++
+		return built
+	})
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	_ = container.Alias("count", "counter")
+
+	if _, err := container.Handle("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Created handle for missing service: %v", err)
+	}
+	counter, err := container.Handle("count")
+	if err != nil {
+		t.Fatalf("Unable to create handle: %s", err)
+	}
+	for i := 0; i < 3; i++ {
+		if v, err := ResolveTyped[int](counter); err != nil || v != 1 {
+			t.Fatalf("Unexpected value %d resolved through handle: %v", v, err)
+		}
+	}
+	if stats := container.Stats()["counter"]; stats.Resolutions != 3 {
+		t.Fatalf("Resolutions through handle not counted: %+v", stats)
+	}
+	if _, err = ResolveTyped[string](counter); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Converted handle to wrong type: %v", err)
+	}
+
+	if err = container.ResetSingleton("counter"); err != nil {
+		t.Fatalf("Unable to reset singleton: %s", err)
+	}
+	if v, _ := ResolveTyped[int](counter); v != 2 {
+		t.Fatalf("Handle resolved stale singleton %d", v)
+	}
+	greeting, _ := container.Handle("greeting")
+	if v, err := ResolveTyped[string](greeting); err != nil || v != "hello" {
+		t.Fatalf("Unable to resolve instanced dependency through handle: %v", err)
+	}
+}
+
+func BenchmarkHandle_Resolve(b *testing.B) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("port", func(resolver ResolverFunc) any {
+		return 8080
+	})
+	h, _ := container.Handle("port")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = h.Resolve()
+	}
+}
@@ -0,0 +1,64 @@
+package godi
+
+import (
+	"context"
+)
+
+// hotSingleton is a built singleton promoted into the lookaside of the
+// Container, so steady-state resolutions skip the lookup of its binding
+// and instance. It is only valid for the generation of the bindings it
+// was promoted in.
+type hotSingleton struct {
+	generation uint64
+	b          *binding
+	stats      *bindingStats
+	value      any
+}
+
+// store binds b under the given name and invalidates all promoted
+// singletons. The caller must hold the mutex of the Container.
+func (d *defaultContainer) store(name string, b *binding) {
+	d.table.services[name] = b
+	d.generation.Add(1)
+}
+
+// storeAlias aliases target as name and invalidates all promoted
+// singletons. The caller must hold the mutex of the Container.
+func (d *defaultContainer) storeAlias(name, target string) {
+	d.table.aliases[name] = target
+	d.generation.Add(1)
+}
+
+// promote adds the value of the built singleton b to the lookaside, if
+// the bindings didn't change since the given generation.
+func (d *defaultContainer) promote(generation uint64, name string, b *binding, stats *bindingStats, value any) {
+	if !b.singleton || b.scoped || b.private || d.generation.Load() != generation {
+		return
+	}
+	d.hot.Store(name, &hotSingleton{generation: generation, b: b, stats: stats, value: value})
+}
+
+// promoted returns the promoted singleton of the given name, unless the
+// resolution is explained, which requires the full lookup.
+func (d *defaultContainer) promoted(ctx context.Context, name string) (*hotSingleton, bool) {
+	entry, ok := d.hot.Load(name)
+	if !ok || treeFrom(ctx) != nil {
+		return nil, false
+	}
+	hot := entry.(*hotSingleton)
+	return hot, hot.generation == d.generation.Load()
+}
+
+// demote removes the named singleton from the lookaside, so its value is
+// no longer referenced by the Container once it was discarded.
+func (d *defaultContainer) demote(name string) {
+	d.hot.Delete(name)
+}
+
+// demoteAll removes all singletons from the lookaside.
+func (d *defaultContainer) demoteAll() {
+	d.hot.Range(func(name, _ any) bool {
+		d.hot.Delete(name)
+		return true
+	})
+}
@@ -0,0 +1,75 @@
+package godi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDefaultContainer_Promoted(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var built int
+	container.MustBindSingleton("counter", func(resolver ResolverFunc) any {
+		built++
+		return built
+	})
+	var hooked int
+	container.OnResolved(func(name string, value any) error {
+		hooked++
+		return nil
+	})
+	d := container
+	ctx := context.Background()
+
+	MustResolve[int]("counter", container.Resolver())
+	if _, ok := d.promoted(ctx, "counter"); !ok {
+		t.Fatalf("Built singleton not promoted")
+	}
+	if v := MustResolve[int]("counter", container.Resolver()); v != 1 || hooked != 2 {
+		t.Fatalf("Promoted singleton resolved as %d with %d hook runs", v, hooked)
+	}
+	if stats := container.Stats()["counter"]; stats.Resolutions != 2 {
+		t.Fatalf("Resolution of promoted singleton not counted: %+v", stats)
+	}
+	if tree, err := container.Explain("counter"); err != nil || !tree.Cached {
+		t.Fatalf("Unable to explain promoted singleton: %v", err)
+	}
+
+	changes := map[string]func() error{
+		"reset": func() error {
+			return container.ResetSingleton("counter")
+		},
+		"deprecate": func() error {
+			return container.Deprecate("counter", "obsolete", "")
+		},
+		"close": func() error {
+			return container.Close(ctx)
+		},
+	}
+	for _, change := range []string{"reset", "deprecate", "close"} {
+		previous := MustResolve[int]("counter", container.Resolver())
+		if err := changes[change](); err != nil {
+			t.Fatalf("Unable to %s singleton: %s", change, err)
+		}
+		if _, ok := d.promoted(ctx, "counter"); ok {
+			t.Fatalf("Promoted singleton not invalidated by %s", change)
+		}
+		v := MustResolve[int]("counter", container.Resolver())
+		if change != "deprecate" && v == previous {
+			t.Fatalf("Stale singleton resolved after %s", change)
+		}
+	}
+}
+
+func BenchmarkResolve_Singleton(b *testing.B) {
+	container := NewContainer()
+	container.MustBindSingleton("port", func(resolver ResolverFunc) any {
+		return 8080
+	})
+	resolver := container.Resolver()
+	MustResolve[int]("port", resolver)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = resolver("port")
+	}
+}
@@ -0,0 +1,45 @@
+package godi
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FindImplementations returns the sorted names of all dependencies bound
+// to the given Container, which implement the interface I. Bindings are
+// matched by their declared type, see DeclareType. Singletons without
+// declared type are probed by their instance, if it was constructed
+// already. Other bindings are never constructed for the lookup.
+func FindImplementations[I any](c Container) []string {
+	iface := typeOf[I]()
+	d, ok := c.(*defaultContainer)
+	if !ok || iface.Kind() != reflect.Interface {
+		return nil
+	}
+	var names []string
+	d.mu.RLock()
+	d.table.eachService(func(name string, b *binding) {
+		if t := d.probeType(b); t != nil && t.Implements(iface) {
+			names = append(names, name)
+		}
+	})
+	d.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// probeType returns the type of the values constructed by the binding b,
+// if it is known without constructing b.
+func (d *defaultContainer) probeType(b *binding) reflect.Type {
+	if b.typ != nil {
+		return b.typ
+	}
+	if !b.singleton {
+		return nil
+	}
+	inst, ok := d.loadInstance(b)
+	if !ok || !inst.built.Load() || inst.value == nil {
+		return nil
+	}
+	return reflect.TypeOf(inst.value)
+}
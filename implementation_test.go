@@ -0,0 +1,43 @@
+package godi
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindImplementations(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("declared", func(resolver ResolverFunc) any {
+		return strings.NewReader("")
+	}, DeclareType(reflect.TypeOf(&strings.Reader{})))
+	container.MustBindSingleton("built", func(resolver ResolverFunc) any {
+		return &bytes.Buffer{}
+	})
+	container.MustBindSingleton("unbuilt", func(resolver ResolverFunc) any {
+		return &bytes.Buffer{}
+	})
+	container.MustBind("undeclared", func(resolver ResolverFunc) any {
+		return &bytes.Buffer{}
+	})
+	if err := BindTypeOf(container, (*io.Reader)(nil), func(resolver ResolverFunc) any {
+		return &bytes.Buffer{}
+	}); err != nil {
+		t.Fatalf("Unable to bind type: %s", err)
+	}
+	MustResolve[*bytes.Buffer]("built", container.Resolver())
+
+	names := FindImplementations[io.Reader](container)
+	expected := []string{"built", "declared", "io.Reader"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Unexpected implementations. Expected %v got %v", expected, names)
+	}
+	if names = FindImplementations[io.Writer](container); !reflect.DeepEqual(names, []string{"built"}) {
+		t.Fatalf("Unexpected implementations of io.Writer %v", names)
+	}
+	if names = FindImplementations[int](container); names != nil {
+		t.Fatalf("Found implementations of non-interface type")
+	}
+}
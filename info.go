@@ -0,0 +1,197 @@
+package godi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// BindingKind describes, how a dependency is bound to a Container.
+type BindingKind int
+
+const (
+	// KindInstanced describes dependencies bound through Container.Bind,
+	// which are instanced on every request.
+	KindInstanced BindingKind = iota
+	// KindSingleton describes dependencies bound through
+	// Container.BindSingleton, which are instanced only once.
+	KindSingleton
+	// KindAlias describes names bound through Container.Alias, which
+	// resolve another dependency.
+	KindAlias
+	// KindPool describes dependencies bound through ExtendedBinder.BindPool,
+	// whose instances are maintained by a Pool.
+	KindPool
+	// KindScoped describes dependencies bound with the Scoped option,
+	// which are instanced once per Scope.
+	KindScoped
+)
+
+func (k BindingKind) String() string {
+	switch k {
+	case KindInstanced:
+		return "instanced"
+	case KindSingleton:
+		return "singleton"
+	case KindAlias:
+		return "alias"
+	case KindPool:
+		return "pool"
+	case KindScoped:
+		return "scoped"
+	}
+	return fmt.Sprintf("BindingKind(%d)", int(k))
+}
+
+// MarshalText encodes the BindingKind by its name.
+func (k BindingKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a BindingKind from its name.
+func (k *BindingKind) UnmarshalText(text []byte) error {
+	for _, kind := range []BindingKind{KindInstanced, KindSingleton, KindAlias, KindPool, KindScoped} {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("unknown binding kind %s", text))
+}
+
+// BindingInfo describes a single name bound to a Container,
+// as reported by Introspector.Info.
+type BindingInfo struct {
+	Name string      `json:"name"`
+	Kind BindingKind `json:"kind"`
+	// Target is the name resolved by an alias.
+	Target string `json:"target,omitempty"`
+	// Built reports, whether the instance of a singleton was constructed already.
+	Built bool `json:"built,omitempty"`
+	// Shared reports, whether the instance of a singleton is shared with
+	// clones of the Container.
+	Shared             bool     `json:"shared,omitempty"`
+	Deprecated         bool     `json:"deprecated,omitempty"`
+	DeprecationMessage string   `json:"deprecationMessage,omitempty"`
+	Replacement        string   `json:"replacement,omitempty"`
+	Tags               []string `json:"tags,omitempty"`
+	Qualifier          string   `json:"qualifier,omitempty"`
+	// Type is the declared type of the values constructed by the binding.
+	Type string `json:"type,omitempty"`
+	// Primary reports, whether the binding was marked through the Primary option.
+	Primary bool `json:"primary,omitempty"`
+	// Resolves lists the dependencies, the binder was recorded to resolve.
+	Resolves []string `json:"resolves,omitempty"`
+	// Location is the source location, the dependency was bound at.
+	Location string `json:"location,omitempty"`
+	// DependsOn lists the dependencies declared through the DependsOn option.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Module is the name of the Module, which installed the binding.
+	Module string `json:"module,omitempty"`
+	// Private reports, whether the binding is private to its Module.
+	Private bool `json:"private,omitempty"`
+	// Priority orders the binding within the groups of its tags.
+	Priority int `json:"priority,omitempty"`
+	// Phase is the startup phase, the singleton is constructed in.
+	Phase string `json:"phase,omitempty"`
+}
+
+func (d *defaultContainer) Info(name string) (BindingInfo, error) {
+	d.mu.RLock()
+	b, ok := d.table.service(name)
+	target, aliased := d.table.alias(name)
+	d.mu.RUnlock()
+	if !ok && aliased {
+		return BindingInfo{Name: name, Kind: KindAlias, Target: target}, nil
+	}
+	if !ok {
+		return BindingInfo{}, &notFoundError{name: name}
+	}
+	info := BindingInfo{Name: name, Kind: b.kind(), Tags: append([]string(nil), b.tags...)}
+	if b.singleton {
+		if inst, ok := d.loadInstance(b); ok {
+			info.Built = inst.built.Load()
+		}
+		info.Shared = b.shared
+	}
+	info.Qualifier = b.qualifier
+	info.Primary = b.primary
+	info.Location = b.location
+	info.Resolves = d.dependencies(name)
+	if b.typ != nil {
+		info.Type = b.typ.String()
+	}
+	info.DependsOn = append([]string(nil), b.dependsOn...)
+	info.Module = b.module
+	info.Private = b.private
+	info.Priority = b.priority
+	info.Phase = b.phase
+	if b.deprecation != nil {
+		info.Deprecated = true
+		info.DeprecationMessage = b.deprecation.message
+		info.Replacement = b.deprecation.replacement
+	}
+	return info, nil
+}
+
+// kind returns the BindingKind describing b.
+func (b *binding) kind() BindingKind {
+	switch {
+	case b.scoped:
+		return KindScoped
+	case b.poolSize > 0:
+		return KindPool
+	case b.singleton:
+		return KindSingleton
+	}
+	return KindInstanced
+}
+
+func (d *defaultContainer) Names() []string {
+	d.mu.RLock()
+	var names []string
+	d.table.eachService(func(name string, _ *binding) {
+		names = append(names, name)
+	})
+	d.table.eachAlias(func(name, _ string) {
+		names = append(names, name)
+	})
+	d.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Description describes all names bound to a Container. It is encoded
+// as a stable JSON document, which can be inspected with the godi command.
+type Description struct {
+	Bindings []BindingInfo `json:"bindings"`
+	// Instances lists the alive instances of a Container in diagnostic
+	// mode, see WithDiagnostics.
+	Instances []InstanceInfo `json:"instances,omitempty"`
+}
+
+func (d *defaultContainer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Describe(d))
+}
+
+// Describe returns the Description of all names bound to the given Container,
+// ordered by their name, and of its alive instances. Containers, which don't
+// implement Introspector, are described as empty.
+func Describe(c Container) Description {
+	introspector, ok := AsIntrospector(c)
+	if !ok {
+		return Description{Bindings: []BindingInfo{}}
+	}
+	names := introspector.Names()
+	description := Description{Bindings: make([]BindingInfo, 0, len(names))}
+	for _, name := range names {
+		info, err := introspector.Info(name)
+		if err != nil {
+			continue
+		}
+		description.Bindings = append(description.Bindings, info)
+	}
+	description.Instances = introspector.Instances()
+	return description
+}
@@ -0,0 +1,102 @@
+package godi
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDefaultContainer_Info(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	handler := func(resolver ResolverFunc) any {
+		return true
+	}
+	container.MustBind("foo", handler)
+	container.MustBindSingleton("bar", handler)
+	if err := container.Alias("baz", "bar"); err != nil {
+		t.Fatalf("Unable to alias dependency %s: %s", "bar", err)
+	}
+	if err := container.Deprecate("foo", "use bar", "bar"); err != nil {
+		t.Fatalf("Unable to deprecate dependency %s: %s", "foo", err)
+	}
+
+	info, err := container.Info("foo")
+	if err != nil {
+		t.Fatalf("Unable to get info of dependency %s: %s", "foo", err)
+	}
+	if info.Kind != KindInstanced || !info.Deprecated || info.Replacement != "bar" {
+		t.Fatalf("Unexpected info for dependency %s: %+v", "foo", info)
+	}
+
+	info, _ = container.Info("bar")
+	if info.Kind != KindSingleton || info.Built {
+		t.Fatalf("Unexpected info for dependency %s: %+v", "bar", info)
+	}
+	MustResolve[bool]("baz", container.Resolver())
+	info, _ = container.Info("bar")
+	if !info.Built {
+		t.Fatalf("Singleton %s not reported as built after resolution", "bar")
+	}
+
+	info, _ = container.Info("baz")
+	if info.Kind != KindAlias || info.Target != "bar" {
+		t.Fatalf("Unexpected info for alias %s: %+v", "baz", info)
+	}
+
+	if _, err = container.Info("qux"); err == nil {
+		t.Fatalf("Got info for non existing dependency %s", "qux")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	handler := func(resolver ResolverFunc) any {
+		return true
+	}
+	_, _, line, _ := runtime.Caller(0)
+	container.MustBindSingleton("foo", handler)
+	container.MustBind("bar", handler)
+	if err := container.Alias("baz", "foo"); err != nil {
+		t.Fatalf("Unable to alias dependency %s: %s", "foo", err)
+	}
+	if names := fmt.Sprint(container.Names()); names != "[bar baz foo godi.clock]" {
+		t.Fatalf("Unexpected names %s", names)
+	}
+
+	data, err := json.Marshal(Describe(container))
+	if err != nil {
+		t.Fatalf("Unable to encode description: %s", err)
+	}
+	expected := fmt.Sprintf(`{"bindings":[{"name":"bar","kind":"instanced","location":"github.com/jschaefer-io/godi/info_test.go:%d"},{"name":"baz","kind":"alias","target":"foo"},{"name":"foo","kind":"singleton","location":"github.com/jschaefer-io/godi/info_test.go:%d"},{"name":"godi.clock","kind":"singleton","type":"godi.Clock"}]}`, line+2, line+1)
+	if string(data) != expected {
+		t.Fatalf("Unexpected description. Got %s expected %s", data, expected)
+	}
+
+	var description Description
+	if err = json.Unmarshal(data, &description); err != nil {
+		t.Fatalf("Unable to decode description: %s", err)
+	}
+	if description.Bindings[1].Kind != KindAlias {
+		t.Fatalf("Unexpected decoded kind %s", description.Bindings[1].Kind)
+	}
+}
+
+func TestDefaultContainer_MarshalJSON(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return true
+	}, Tags("http"), DependsOn("bar"))
+	data, err := json.Marshal(container)
+	if err != nil {
+		t.Fatalf("Unable to encode container: %s", err)
+	}
+	expected, _ := json.Marshal(Describe(container))
+	if string(data) != string(expected) {
+		t.Fatalf("Unexpected encoding. Got %s expected %s", data, expected)
+	}
+	if !strings.Contains(string(data), `"dependsOn":["bar"]`) {
+		t.Fatalf("Encoding misses dependency edges: %s", data)
+	}
+}
@@ -0,0 +1,143 @@
+package godi
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// WithInstanceTracking tracks the instances constructed by the Container.
+// BindingStats.Alive reports the number of instances of each dependency,
+// which are currently alive. Instances are alive from their construction,
+// until they are closed by the Container or a Scope, or garbage collected.
+// A steadily rising number of alive instances indicates a leak. Only
+// instances constructed as pointers are tracked.
+func WithInstanceTracking() ContainerOption {
+	return func(d *defaultContainer) {
+		d.tracking = true
+	}
+}
+
+// WithDiagnostics tracks the instances constructed by the Container like
+// WithInstanceTracking and additionally records, where each alive instance
+// came from. Recorded instances are listed by Introspector.Instances and the
+// Description of the Container, so a debug endpoint serving it answers,
+// which resolution created an object.
+func WithDiagnostics() ContainerOption {
+	return func(d *defaultContainer) {
+		d.tracking = true
+		d.diagnostics = true
+	}
+}
+
+// InstanceInfo describes an alive instance constructed by a Container in
+// diagnostic mode, see WithDiagnostics.
+type InstanceInfo struct {
+	// ID identifies the instance within its Container, in the order of
+	// construction.
+	ID      uint64    `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	// Chain lists the dependencies, whose construction resolved the
+	// instance, outermost first.
+	Chain []string `json:"chain,omitempty"`
+}
+
+// trackedInstance is an instance alive until it is released. Its info is
+// only recorded in diagnostic mode.
+type trackedInstance struct {
+	stats    *bindingStats
+	released atomic.Bool
+	info     InstanceInfo
+}
+
+// release marks the instance as no longer alive. Repeated releases have
+// no further effect.
+func (t *trackedInstance) release() {
+	if t.released.CompareAndSwap(false, true) {
+		t.stats.alive.Add(-1)
+	}
+}
+
+// trackingKey returns the address identifying the given instance, if it
+// can be tracked.
+func trackingKey(value any) (uintptr, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Type().Elem().Size() == 0 {
+		return 0, false
+	}
+	return v.Pointer(), true
+}
+
+// trackInstance records the construction of the given instance of the
+// named dependency within the given context, if instance tracking is
+// enabled. The instance is released once it is garbage collected.
+func (d *defaultContainer) trackInstance(ctx context.Context, name string, value any) {
+	if !d.tracking {
+		return
+	}
+	key, ok := trackingKey(value)
+	if !ok {
+		return
+	}
+	t := &trackedInstance{stats: d.statsOf(name)}
+	if d.diagnostics {
+		t.info = InstanceInfo{
+			ID:      d.instanceIDs.Add(1),
+			Name:    name,
+			Created: time.Now(),
+			Chain:   dependentFrom(ctx).chain(),
+		}
+	}
+	t.stats.alive.Add(1)
+	if previous, ok := d.tracked.Swap(key, t); ok {
+		previous.(*trackedInstance).release()
+	}
+	onCollect(value, func() {
+		d.tracked.CompareAndDelete(key, t)
+		t.release()
+	})
+}
+
+// releaseInstance releases the given instance after it was closed.
+func (d *defaultContainer) releaseInstance(value any) {
+	if !d.tracking {
+		return
+	}
+	key, ok := trackingKey(value)
+	if !ok {
+		return
+	}
+	if t, ok := d.tracked.LoadAndDelete(key); ok {
+		t.(*trackedInstance).release()
+	}
+}
+
+func (d *defaultContainer) Instances() []InstanceInfo {
+	if !d.diagnostics {
+		return nil
+	}
+	var instances []InstanceInfo
+	d.tracked.Range(func(_, t any) bool {
+		instances = append(instances, t.(*trackedInstance).info)
+		return true
+	})
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].ID < instances[j].ID
+	})
+	return instances
+}
+
+func (d *defaultContainer) InstanceOf(value any) (InstanceInfo, bool) {
+	key, ok := trackingKey(value)
+	if !ok || !d.diagnostics {
+		return InstanceInfo{}, false
+	}
+	t, ok := d.tracked.Load(key)
+	if !ok {
+		return InstanceInfo{}, false
+	}
+	return t.(*trackedInstance).info, true
+}
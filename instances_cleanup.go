@@ -0,0 +1,15 @@
+//go:build go1.24
+
+package godi
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// onCollect calls release, once the given pointer was garbage collected.
+func onCollect(value any, release func()) {
+	runtime.AddCleanup((*byte)(reflect.ValueOf(value).UnsafePointer()), func(release func()) {
+		release()
+	}, release)
+}
@@ -0,0 +1,8 @@
+//go:build !go1.24
+
+package godi
+
+// onCollect is a no-op, as instances can't be observed being garbage
+// collected before Go 1.24. Tracked instances are only released once
+// they are closed.
+func onCollect(value any, release func()) {}
@@ -0,0 +1,106 @@
+package godi
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+type trackedConnection struct {
+	buffer [64]byte
+	closed bool
+}
+
+func (c *trackedConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestWithInstanceTracking(t *testing.T) {
+	container := NewContainer(WithInstanceTracking()).(*defaultContainer)
+	container.MustBind("connection", func(resolver ResolverFunc) any {
+		return &trackedConnection{}
+	})
+	container.MustBind("session", func(resolver ResolverFunc) any {
+		return &trackedConnection{}
+	}, Scoped())
+	container.MustBind("value", func(resolver ResolverFunc) any {
+		return 1
+	})
+
+	scope := container.NewScope(TrackTransients())
+	connections := []*trackedConnection{
+		MustResolve[*trackedConnection]("connection", scope.Resolver()),
+		MustResolve[*trackedConnection]("connection", scope.Resolver()),
+	}
+	MustResolve[*trackedConnection]("session", scope.Resolver())
+	MustResolve[int]("value", container.Resolver())
+	stats := container.Stats()
+	if stats["connection"].Alive != 2 || stats["session"].Alive != 1 || stats["value"].Alive != 0 {
+		t.Fatalf("Unexpected alive instances %+v", stats)
+	}
+
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Unable to close scope: %s", err)
+	}
+	stats = container.Stats()
+	if stats["connection"].Alive != 0 || stats["session"].Alive != 0 {
+		t.Fatalf("Closed instances still alive %+v", stats)
+	}
+	runtime.KeepAlive(connections)
+
+	MustResolve[*trackedConnection]("connection", container.Resolver())
+	deadline := time.Now().Add(time.Second)
+	for container.Stats()["connection"].Alive != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Collected instance still alive")
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithInstanceTracking_Disabled(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("connection", func(resolver ResolverFunc) any {
+		return &trackedConnection{}
+	})
+	connection := MustResolve[*trackedConnection]("connection", container.Resolver())
+	if alive := container.Stats()["connection"].Alive; alive != 0 {
+		t.Fatalf("Untracked instances reported alive: %d", alive)
+	}
+	runtime.KeepAlive(connection)
+}
+
+func TestWithDiagnostics(t *testing.T) {
+	container := NewContainer(WithDiagnostics()).(*defaultContainer)
+	container.MustBind("connection", func(resolver ResolverFunc) any {
+		return &trackedConnection{}
+	})
+	var created *trackedConnection
+	container.MustBindSingleton("repository", func(resolver ResolverFunc) any {
+		created = MustResolve[*trackedConnection]("connection", resolver)
+		return &trackedConnection{}
+	})
+	direct := MustResolve[*trackedConnection]("connection", container.Resolver())
+	MustResolve[*trackedConnection]("repository", container.Resolver())
+
+	info, ok := container.InstanceOf(created)
+	if !ok || info.Name != "connection" || !reflect.DeepEqual(info.Chain, []string{"repository"}) || info.Created.IsZero() {
+		t.Fatalf("Unexpected info of nested instance %+v", info)
+	}
+	info, ok = container.InstanceOf(direct)
+	if !ok || info.ID != 1 || info.Name != "connection" || len(info.Chain) != 0 {
+		t.Fatalf("Unexpected info of direct instance %+v", info)
+	}
+	if instances := Describe(container).Instances; len(instances) != 3 || instances[0].ID != 1 {
+		t.Fatalf("Unexpected described instances %+v", instances)
+	}
+	if _, ok = container.InstanceOf(&trackedConnection{}); ok {
+		t.Fatalf("Reported info of foreign instance")
+	}
+	if _, ok = NewContainer(WithInstanceTracking()).(*defaultContainer).InstanceOf(direct); ok {
+		t.Fatalf("Reported info without diagnostic mode")
+	}
+}
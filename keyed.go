@@ -0,0 +1,98 @@
+package godi
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CacheOption configures a factory created by CachedFactory.
+type CacheOption func(o *cacheOptions)
+
+type cacheOptions struct {
+	maxEntries int
+}
+
+// MaxEntries limits the number of values cached by a CachedFactory. Once
+// the limit is exceeded, the least recently used value is evicted and
+// constructed anew on its next request. Evicted values aren't closed, as
+// callers may still use them.
+func MaxEntries(n int) CacheOption {
+	return func(o *cacheOptions) {
+		o.maxEntries = n
+	}
+}
+
+// keyedEntry is a value of a CachedFactory, which is constructed once.
+type keyedEntry[K comparable, T any] struct {
+	key   K
+	mu    sync.Mutex
+	built bool
+	value T
+}
+
+// CachedFactory memoizes a factory taking arguments, like one constructing
+// a client per tenant. The result of factory is cached per key derived
+// from its argument by the key function, so every key is constructed once,
+// even if it is requested concurrently. Failed constructions aren't cached
+// and are retried on the next request. Without MaxEntries, the cache is
+// unbounded.
+//
+//	clients := godi.CachedFactory(newTenantClient, func(t Tenant) string {
+//		return t.ID
+//	}, godi.MaxEntries(100))
+func CachedFactory[A any, K comparable, T any](factory func(A) (T, error), key func(A) K, options ...CacheOption) func(A) (T, error) {
+	var o cacheOptions
+	for _, option := range options {
+		option(&o)
+	}
+	var mu sync.Mutex
+	entries := make(map[K]*list.Element)
+	recent := list.New()
+
+	// acquire returns the entry of k, marking it as the most recently used.
+	acquire := func(k K) *list.Element {
+		mu.Lock()
+		defer mu.Unlock()
+		if elem, ok := entries[k]; ok {
+			recent.MoveToFront(elem)
+			return elem
+		}
+		elem := recent.PushFront(&keyedEntry[K, T]{key: k})
+		entries[k] = elem
+		if o.maxEntries > 0 && recent.Len() > o.maxEntries {
+			oldest := recent.Back()
+			recent.Remove(oldest)
+			delete(entries, oldest.Value.(*keyedEntry[K, T]).key)
+		}
+		return elem
+	}
+	// discard removes the entry of a failed construction, unless it was
+	// evicted already.
+	discard := func(elem *list.Element) {
+		mu.Lock()
+		defer mu.Unlock()
+		k := elem.Value.(*keyedEntry[K, T]).key
+		if entries[k] == elem {
+			recent.Remove(elem)
+			delete(entries, k)
+		}
+	}
+
+	return func(arg A) (T, error) {
+		elem := acquire(key(arg))
+		entry := elem.Value.(*keyedEntry[K, T])
+		entry.mu.Lock()
+		defer entry.mu.Unlock()
+		if entry.built {
+			return entry.value, nil
+		}
+		value, err := factory(arg)
+		if err != nil {
+			discard(elem)
+			return value, err
+		}
+		entry.value = value
+		entry.built = true
+		return value, nil
+	}
+}
@@ -0,0 +1,71 @@
+package godi
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCachedFactory(t *testing.T) {
+	type tenant struct {
+		id string
+	}
+	var builds atomic.Int32
+	clients := CachedFactory(func(t tenant) (string, error) {
+		builds.Add(1)
+		if t.id == "" {
+			return "", errors.New("unknown tenant")
+		}
+		return "client of " + t.id, nil
+	}, func(t tenant) string {
+		return t.id
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := clients(tenant{id: "acme"}); err != nil || v != "client of acme" {
+				t.Errorf("Unexpected client %s: %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if builds.Load() != 1 {
+		t.Fatalf("Client constructed %d times for one tenant", builds.Load())
+	}
+	_, _ = clients(tenant{id: "globex"})
+	if builds.Load() != 2 {
+		t.Fatalf("Client not constructed per tenant")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := clients(tenant{}); err == nil {
+			t.Fatalf("Failed construction not reported")
+		}
+	}
+	if builds.Load() != 4 {
+		t.Fatalf("Failed construction cached")
+	}
+}
+
+func TestCachedFactory_MaxEntries(t *testing.T) {
+	builds := make(map[int]int)
+	squares := CachedFactory(func(n int) (int, error) {
+		builds[n]++
+		return n * n, nil
+	}, func(n int) int {
+		return n
+	}, MaxEntries(2))
+
+	for _, n := range []int{1, 2, 1, 3, 1, 2} {
+		if v, _ := squares(n); v != n*n {
+			t.Fatalf("Unexpected value %d of %d", v, n)
+		}
+	}
+	if builds[1] != 1 || builds[2] != 2 || builds[3] != 1 {
+		t.Fatalf("Least recently used entry not evicted: %v", builds)
+	}
+}
@@ -0,0 +1,43 @@
+package godi
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// packageDir is the directory of the godi package sources, whose frames
+// are skipped when determining bind locations.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// bindLocation returns the location of the call binding a dependency, as
+// the first frame outside the godi package. It is reported by package
+// path, file and line, like "github.com/acme/app/user/wiring.go:42", so
+// it is independent of the machine the binary was built on.
+func bindLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if frame.File != "" && (filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go")) {
+			return packagePath(frame.Function) + "/" + filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// packagePath extracts the package path from the fully qualified name of
+// a function, like "github.com/acme/app/user.(*Module).Wire".
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
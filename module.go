@@ -0,0 +1,336 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Module is a named ProviderSet, which encapsulates its implementation
+// details. Bindings marked through Private are only resolvable by the
+// binders of the same Module, but neither by application code nor by
+// other Modules. A Module declaring an export list through Export treats
+// all bindings not exported as private, like a package with a public API.
+// Modules may declare the services they require from the application or
+// other Modules through Requires, and the services they provide through
+// Provides. Modules are immutable values like ProviderSets.
+//
+//	var Storage = godi.NewModule("storage", godi.NewProviderSet().
+//		BindSingleton("pool", newPool).
+//		Bind("repository", newRepository)).
+//		Export("repository")
+//
+// Modules are bound to a Container through Composer.Install.
+type Module struct {
+	name      string
+	set       ProviderSet
+	private   []string
+	exports   []string
+	exporting bool
+	requires  []string
+	provides  []string
+}
+
+// NewModule creates a Module of the given name including all entries of
+// the given sets.
+func NewModule(name string, sets ...ProviderSet) Module {
+	return Module{name: name, set: NewProviderSet(sets...)}
+}
+
+// Name returns the name of the Module.
+func (m Module) Name() string {
+	return m.name
+}
+
+// Private returns a Module additionally marking the named bindings as
+// private to the Module.
+func (m Module) Private(names ...string) Module {
+	m.private = append(slices.Clip(m.private), names...)
+	return m
+}
+
+// Export returns a Module additionally exporting the named bindings and
+// aliases. Once a Module declares exports, all of its bindings, which
+// are not exported, are private.
+func (m Module) Export(names ...string) Module {
+	m.exports = append(slices.Clip(m.exports), names...)
+	m.exporting = true
+	return m
+}
+
+// Requires returns a Module additionally declaring, that the named
+// services must be provided by the application or another Module.
+func (m Module) Requires(names ...string) Module {
+	m.requires = append(slices.Clip(m.requires), names...)
+	return m
+}
+
+// Provides returns a Module additionally declaring, that it provides the
+// named services to the application and other Modules.
+func (m Module) Provides(names ...string) Module {
+	m.provides = append(slices.Clip(m.provides), names...)
+	return m
+}
+
+// public returns the names of all bindings and aliases, the Module makes
+// available outside of itself.
+func (m Module) public() []string {
+	var names []string
+	for _, name := range m.set.Names() {
+		if !m.isPrivate(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isPrivate reports, whether the named binding is private to the Module.
+func (m Module) isPrivate(name string) bool {
+	return slices.Contains(m.private, name) || m.exporting && !slices.Contains(m.exports, name)
+}
+
+// validate reports private and exported names, which the Module doesn't
+// provide.
+func (m Module) validate() error {
+	var errs []error
+	for _, name := range m.private {
+		if !slices.ContainsFunc(m.set.providers, func(entry provider) bool { return entry.name == name }) {
+			errs = append(errs, errors.New(fmt.Sprintf("private service %s not bound by module %s", name, m.name)))
+		}
+	}
+	for _, name := range m.exports {
+		if !slices.Contains(m.set.Names(), name) {
+			errs = append(errs, errors.New(fmt.Sprintf("exported service %s not provided by module %s", name, m.name)))
+		}
+	}
+	for _, name := range m.provides {
+		if !slices.Contains(m.public(), name) {
+			errs = append(errs, errors.New(fmt.Sprintf("module %s declares to provide service %s, which it doesn't provide", m.name, name)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unmetRequirements reports all services required by the given Modules,
+// which are neither provided by any of them nor bound to the Container.
+func (d *defaultContainer) unmetRequirements(modules []Module) error {
+	provided := make(map[string]bool)
+	for _, module := range modules {
+		for _, name := range module.public() {
+			provided[name] = true
+		}
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var errs []error
+	for _, module := range modules {
+		for _, name := range module.requires {
+			if provided[name] || slices.Contains(module.set.Names(), name) {
+				continue
+			}
+			if b, ok := d.table.service(name); ok && !b.private {
+				continue
+			}
+			if _, ok := d.table.alias(name); ok {
+				continue
+			}
+			if _, _, ok := d.mounted(name); ok {
+				continue
+			}
+			errs = append(errs, errors.New(fmt.Sprintf("module %s requires service %s, which nothing provides", module.name, name)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// exportCollisions reports all names exported by more than one of the
+// given Modules.
+func exportCollisions(modules []Module) error {
+	var errs []error
+	exporters := make(map[string]string)
+	for _, module := range modules {
+		for _, name := range module.exports {
+			if other, ok := exporters[name]; ok && other != module.name {
+				errs = append(errs, errors.New(fmt.Sprintf("service %s exported by modules %s and %s", name, other, module.name)))
+				continue
+			}
+			exporters[name] = module.name
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// inModule installs a binding as part of the named Module.
+func inModule(module string, private bool) BindOption {
+	return func(o *bindOptions) {
+		o.module = module
+		o.private = private
+	}
+}
+
+func (d *defaultContainer) Install(modules ...Module) error {
+	if d.collisionPolicy == CollisionPrefix {
+		modules = slices.Clone(modules)
+		for i, module := range modules {
+			modules[i] = module.prefixed()
+		}
+	}
+	var set ProviderSet
+	for _, module := range modules {
+		if module.name == "" {
+			return errors.New("unable to install module. name must not be empty")
+		}
+		if err := module.validate(); err != nil {
+			return fmt.Errorf("unable to install module %s: %w", module.name, err)
+		}
+		set = set.Include(module.set)
+	}
+	if err := exportCollisions(modules); err != nil {
+		return fmt.Errorf("unable to install modules: %w", err)
+	}
+	if err := d.unmetRequirements(modules); err != nil {
+		return fmt.Errorf("unable to install modules: %w", err)
+	}
+	if err := set.validate(d); err != nil {
+		return fmt.Errorf("unable to install modules: %w", err)
+	}
+	for _, module := range modules {
+		providers := make([]provider, len(module.set.providers))
+		for i, entry := range module.set.providers {
+			entry.options = append(slices.Clip(entry.options), inModule(module.name, module.isPrivate(entry.name)))
+			providers[i] = entry
+		}
+		installed := module.set
+		installed.providers = providers
+		if err := d.Apply(installed); err != nil {
+			return fmt.Errorf("unable to install module %s: %w", module.name, err)
+		}
+		if d.collisionPolicy == CollisionPrefix && len(installed.providers) > 0 {
+			d.prefixing.Store(true)
+		}
+	}
+	return nil
+}
+
+// privateError reports the resolution of a private binding from outside
+// of its Module.
+type privateError struct {
+	module string
+}
+
+func (e *privateError) Error() string {
+	return "private to module " + e.module
+}
+
+func (e *privateError) Unwrap() error {
+	return ErrNotFound
+}
+
+type trustedKey struct{}
+
+// trusted marks resolutions performed by the Container itself, like
+// warming up eager singletons, which may construct private bindings.
+func trusted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, trustedKey{}, true)
+}
+
+// visible reports, whether the binding b may be resolved within the given
+// context. Private bindings are visible to the binders of their Module,
+// resolution plans and the Container itself.
+func visible(ctx context.Context, b *binding) bool {
+	if !b.private {
+		return true
+	}
+	if dep := dependentFrom(ctx); dep != nil {
+		return dep.module == b.module
+	}
+	return ctx.Value(planKey{}) != nil || ctx.Value(trustedKey{}) != nil
+}
+
+// CollisionPolicy decides, how Composer.Install handles names bound by
+// more than one Module.
+type CollisionPolicy int
+
+const (
+	// CollisionError rejects names bound by more than one Module. It is
+	// the default policy.
+	CollisionError CollisionPolicy = iota
+	// CollisionPrefix binds the private bindings of every Module as
+	// "<module>.<name>", so independently developed Modules compose
+	// without coordinating their private names. Binders of a Module still
+	// resolve its private bindings by their plain names. Exported names
+	// must be unique regardless.
+	CollisionPrefix
+)
+
+func (p CollisionPolicy) String() string {
+	switch p {
+	case CollisionError:
+		return "error"
+	case CollisionPrefix:
+		return "prefix"
+	}
+	return fmt.Sprintf("CollisionPolicy(%d)", int(p))
+}
+
+// WithCollisionPolicy sets the CollisionPolicy of Composer.Install. By
+// default, colliding names are rejected.
+func WithCollisionPolicy(policy CollisionPolicy) ContainerOption {
+	return func(d *defaultContainer) {
+		d.collisionPolicy = policy
+	}
+}
+
+// prefixed returns the Module with all of its private bindings renamed
+// to "<module>.<name>", including the aliases and tags referring to them.
+func (m Module) prefixed() Module {
+	renamed := make(map[string]string)
+	rename := func(name string) string {
+		if prefixed, ok := renamed[name]; ok {
+			return prefixed
+		}
+		return name
+	}
+	providers := make([]provider, len(m.set.providers))
+	for i, entry := range m.set.providers {
+		if m.isPrivate(entry.name) {
+			renamed[entry.name] = m.name + "." + entry.name
+			entry.name = renamed[entry.name]
+		}
+		providers[i] = entry
+	}
+	aliases := make([]providerAlias, len(m.set.aliases))
+	for i, entry := range m.set.aliases {
+		aliases[i] = providerAlias{name: entry.name, target: rename(entry.target)}
+	}
+	tags := make([]providerTag, len(m.set.tags))
+	for i, entry := range m.set.tags {
+		tags[i] = providerTag{name: rename(entry.name), tags: entry.tags}
+	}
+	private := make([]string, len(m.private))
+	for i, name := range m.private {
+		private[i] = rename(name)
+	}
+	m.set = ProviderSet{providers: providers, aliases: aliases, tags: tags}
+	m.private = private
+	return m
+}
+
+// localName returns the name of the private binding, which the binders
+// of the Module resolving within the given context refer to by the given
+// plain name, if its private bindings were prefixed.
+func (d *defaultContainer) localName(ctx context.Context, name string) string {
+	dep := dependentFrom(ctx)
+	if dep == nil || dep.module == "" {
+		return name
+	}
+	local := dep.module + "." + name
+	d.mu.RLock()
+	b, ok := d.table.service(local)
+	d.mu.RUnlock()
+	if ok && b.private && b.module == dep.module {
+		return local
+	}
+	return name
+}
@@ -0,0 +1,163 @@
+package godi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDefaultContainer_Install(t *testing.T) {
+	storage := NewModule("storage", NewProviderSet().
+		BindSingleton("pool", func(resolver ResolverFunc) any {
+			return "pool"
+		}, Eager()).
+		Bind("repository", func(resolver ResolverFunc) any {
+			return "repository on " + MustResolve[string]("pool", resolver)
+		})).
+		Private("pool")
+	api := NewModule("api", NewProviderSet().
+		Bind("handler", func(resolver ResolverFunc) any {
+			_, err := resolver("pool")
+			return err
+		}))
+
+	container := NewContainer().(*defaultContainer)
+	if err := container.Install(storage, api); err != nil {
+		t.Fatalf("Unable to install modules: %s", err)
+	}
+	container.Lock()
+	resolver := container.Resolver()
+	if v := MustResolve[string]("repository", resolver); v != "repository on pool" {
+		t.Fatalf("Private binding not resolvable within its module: %s", v)
+	}
+	if err := MustResolve[error]("handler", resolver); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Private binding resolvable by other module: %v", err)
+	}
+	_, err := resolver("pool")
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "private to module storage") {
+		t.Fatalf("Private binding resolvable by application: %v", err)
+	}
+	if _, err = container.Handle("pool"); err == nil {
+		t.Fatalf("Created handle of private binding")
+	}
+	if info, _ := container.Info("pool"); info.Module != "storage" || !info.Private {
+		t.Fatalf("Unexpected info of private binding %+v", info)
+	}
+
+	if err = NewContainer().(*defaultContainer).Install(storage, storage); err == nil {
+		t.Fatalf("Installed module twice")
+	}
+	if err = NewContainer().(*defaultContainer).Install(NewModule("broken").Private("missing")); err == nil {
+		t.Fatalf("Installed module marking unbound name as private")
+	}
+}
+
+func TestModule_Export(t *testing.T) {
+	binder := func(value string) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			return value
+		}
+	}
+	users := NewModule("users", NewProviderSet().
+		Bind("users.store", binder("store")).
+		Bind("users.service", func(resolver ResolverFunc) any {
+			return "service on " + MustResolve[string]("users.store", resolver)
+		}).
+		Alias("users", "users.service")).
+		Export("users", "users.service")
+
+	container := NewContainer().(*defaultContainer)
+	if err := container.Install(users); err != nil {
+		t.Fatalf("Unable to install module: %s", err)
+	}
+	resolver := container.Resolver()
+	if v := MustResolve[string]("users", resolver); v != "service on store" {
+		t.Fatalf("Exported binding not resolvable: %s", v)
+	}
+	if _, err := resolver("users.store"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Binding missing from export list resolvable: %v", err)
+	}
+
+	orders := NewModule("orders", NewProviderSet().Bind("users", binder("orders"))).Export("users")
+	err := NewContainer().(*defaultContainer).Install(users, orders)
+	if err == nil || !strings.Contains(err.Error(), "service users exported by modules users and orders") {
+		t.Fatalf("Export collision not reported: %v", err)
+	}
+	if err = NewContainer().(*defaultContainer).Install(NewModule("broken").Export("missing")); err == nil {
+		t.Fatalf("Installed module exporting unknown name")
+	}
+}
+
+func TestModule_Requires(t *testing.T) {
+	binder := func(resolver ResolverFunc) any {
+		return true
+	}
+	billing := NewModule("billing", NewProviderSet().Bind("invoices", binder)).
+		Requires("db", "mailer").
+		Provides("invoices")
+	storage := NewModule("storage", NewProviderSet().Bind("db", binder)).Provides("db")
+
+	err := NewContainer().(*defaultContainer).Install(billing, storage)
+	if err == nil || !strings.Contains(err.Error(), "module billing requires service mailer, which nothing provides") {
+		t.Fatalf("Unmet requirement not reported: %v", err)
+	}
+	if strings.Contains(err.Error(), "service db") {
+		t.Fatalf("Requirement provided by other module reported: %s", err)
+	}
+
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("mailer", binder)
+	if err = container.Install(billing, storage); err != nil {
+		t.Fatalf("Unable to install modules with met requirements: %s", err)
+	}
+
+	hidden := NewModule("hidden", NewProviderSet().Bind("mailer", binder)).Private("mailer")
+	if err = NewContainer().(*defaultContainer).Install(billing, storage, hidden); err == nil {
+		t.Fatalf("Requirement met by private binding of other module")
+	}
+	if err = NewContainer().(*defaultContainer).Install(NewModule("broken").Provides("missing")); err == nil {
+		t.Fatalf("Installed module not providing declared service")
+	}
+}
+
+func TestWithCollisionPolicy(t *testing.T) {
+	module := func(name string) Module {
+		return NewModule(name, NewProviderSet().
+			BindSingleton("config", func(resolver ResolverFunc) any {
+				return name + " config"
+			}).
+			Bind(name, func(resolver ResolverFunc) any {
+				return MustResolve[string]("config", resolver)
+			}, DependsOn("config")).
+			Tag("config", "configs")).
+			Export(name)
+	}
+
+	if err := NewContainer().(*defaultContainer).Install(module("users"), module("orders")); err == nil {
+		t.Fatalf("Installed modules with colliding private names")
+	}
+	container := NewContainer(WithCollisionPolicy(CollisionPrefix)).(*defaultContainer)
+	container.MustBindSingleton("config", func(resolver ResolverFunc) any {
+		return "app config"
+	})
+	if err := container.Install(module("users"), module("orders")); err != nil {
+		t.Fatalf("Unable to install modules with prefixed private names: %s", err)
+	}
+	resolver := container.Resolver()
+	MustResolve[string]("config", resolver)
+	if v := MustResolve[string]("users", resolver); v != "users config" {
+		t.Fatalf("Module resolved foreign private binding: %s", v)
+	}
+	if v := MustResolve[string]("orders", resolver); v != "orders config" {
+		t.Fatalf("Module resolved foreign private binding: %s", v)
+	}
+	if _, err := resolver("users.config"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Prefixed private binding resolvable by application: %v", err)
+	}
+	if tagged := container.Tagged("configs"); len(tagged) != 2 || tagged[0] != "orders.config" {
+		t.Fatalf("Tags not applied to prefixed names: %v", tagged)
+	}
+	if err := container.Install(NewModule("more", NewProviderSet().Bind("users", nil)).Export("users")); err == nil {
+		t.Fatalf("Installed module with colliding export")
+	}
+}
@@ -0,0 +1,138 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// mount is a Container mounted under a prefix. Mounts inherited from the
+// Container a clone was created from are owned by that Container, so they
+// aren't closed together with the clone.
+type mount struct {
+	container Container
+	inherited bool
+}
+
+// inherit returns the mounts of a clone of the Container with the given
+// mounts.
+func inherit(mounts map[string]mount) map[string]mount {
+	if mounts == nil {
+		return nil
+	}
+	inherited := make(map[string]mount, len(mounts))
+	for prefix, m := range mounts {
+		inherited[prefix] = mount{container: m.container, inherited: true}
+	}
+	return inherited
+}
+
+func (d *defaultContainer) Mount(prefix string, other Container) error {
+	if prefix == "" {
+		return errors.New("unable to mount container. prefix must not be empty")
+	}
+	if other == nil || other == Container(d) {
+		return errors.New(fmt.Sprintf("unable to mount container at %s. a container can't mount itself", prefix))
+	}
+	if mounts(other, d) {
+		return errors.New(fmt.Sprintf("unable to mount container at %s. it mounts this container, which would create a cycle", prefix))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to mount container at %s: %w", prefix, ErrFrozen)
+	}
+	if d.locked.Load() {
+		return errors.New("service container locked. no more containers can be mounted")
+	}
+	if _, ok := d.mounts[prefix]; ok {
+		return errors.New(fmt.Sprintf("container already mounted at %s", prefix))
+	}
+	if d.mounts == nil {
+		d.mounts = make(map[string]mount)
+	}
+	d.mounts[prefix] = mount{container: other}
+	return nil
+}
+
+// mounts reports, whether the Container c mounts the Container target,
+// directly or through the Containers it mounts. Only the mounts of
+// Containers created by NewContainer are known.
+func mounts(c Container, target *defaultContainer) bool {
+	visited := make(map[*defaultContainer]bool)
+	var walk func(c Container) bool
+	walk = func(c Container) bool {
+		dc, ok := c.(*defaultContainer)
+		if !ok || visited[dc] {
+			return false
+		}
+		if dc == target {
+			return true
+		}
+		visited[dc] = true
+		dc.mu.RLock()
+		mounted := make([]Container, 0, len(dc.mounts))
+		for _, m := range dc.mounts {
+			mounted = append(mounted, m.container)
+		}
+		dc.mu.RUnlock()
+		for _, next := range mounted {
+			if walk(next) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(c)
+}
+
+// mounted returns the Container mounted under the longest prefix of the
+// given name and the name within it. The caller must hold the mutex of
+// the Container.
+func (d *defaultContainer) mounted(name string) (Container, string, bool) {
+	var match string
+	for prefix := range d.mounts {
+		if len(prefix) > len(match) && strings.HasPrefix(name, prefix+".") {
+			match = prefix
+		}
+	}
+	if match == "" {
+		return nil, "", false
+	}
+	return d.mounts[match].container, name[len(match)+1:], true
+}
+
+// detach strips the state of a resolution from its context, so it can be
+// continued by another Container.
+func detach(ctx context.Context) context.Context {
+	ctx = context.WithValue(withoutScope(ctx), dependentKey{}, (*dependent)(nil))
+	return context.WithValue(ctx, planKey{}, nil)
+}
+
+// closeMounts closes all Containers mounted by the Container itself in
+// order of their prefixes. Inherited mounts are left open.
+func (d *defaultContainer) closeMounts(ctx context.Context) error {
+	d.mu.RLock()
+	prefixes := make([]string, 0, len(d.mounts))
+	for prefix, m := range d.mounts {
+		if !m.inherited {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	mounted := d.mounts
+	d.mu.RUnlock()
+	sort.Strings(prefixes)
+	var errs []error
+	for _, prefix := range prefixes {
+		closer, ok := AsCloser(mounted[prefix].container)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("unable to close container mounted at %s: %w", prefix, err))
+		}
+	}
+	return errors.Join(errs...)
+}
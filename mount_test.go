@@ -0,0 +1,85 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDefaultContainer_Mount(t *testing.T) {
+	billing := NewContainer()
+	billing.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	billing.MustBind("invoices", func(resolver ResolverFunc) any {
+		MustResolve[*closeRecorder]("db", resolver)
+		return "invoices"
+	})
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("billing.invoices", func(resolver ResolverFunc) any {
+		return "overridden"
+	})
+	container.MustBind("api", func(resolver ResolverFunc) any {
+		return MustResolve[*closeRecorder]("billing.db", resolver)
+	})
+	if err := container.Mount("billing", billing); err != nil {
+		t.Fatalf("Unable to mount container: %s", err)
+	}
+	if err := container.Mount("billing", NewContainer()); err == nil {
+		t.Fatalf("Mounted two containers at %s", "billing")
+	}
+
+	resolver := container.Resolver()
+	db := MustResolve[*closeRecorder]("api", resolver)
+	if db != MustResolve[*closeRecorder]("db", billing.Resolver()) {
+		t.Fatalf("Mounted singleton %s not shared with mounted container", "db")
+	}
+	if v := MustResolve[string]("billing.invoices", resolver); v != "overridden" {
+		t.Fatalf("Mounted dependency took precedence over bound one: %s", v)
+	}
+	if _, err := resolver("billing.missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Resolved missing mounted dependency: %v", err)
+	}
+
+	if err := container.Close(context.Background()); err != nil {
+		t.Fatalf("Unable to close container: %s", err)
+	}
+	if !db.closed {
+		t.Fatalf("Mounted container not closed")
+	}
+	container.Lock()
+	if err := container.Mount("shipping", NewContainer()); err == nil {
+		t.Fatalf("Mounted container on locked container")
+	}
+}
+
+func TestDefaultContainer_Mount_Clone(t *testing.T) {
+	billing := NewContainer().(*defaultContainer)
+	billing.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	container := NewContainer().(*defaultContainer)
+	if err := container.Mount("billing", billing); err != nil {
+		t.Fatalf("Unable to mount container: %s", err)
+	}
+	db := MustResolve[*closeRecorder]("billing.db", container.Resolver())
+
+	clone := container.Clone().(*defaultContainer)
+	if MustResolve[*closeRecorder]("billing.db", clone.Resolver()) != db {
+		t.Fatalf("Mounted container not inherited by clone")
+	}
+	if err := clone.Close(context.Background()); err != nil || db.closed {
+		t.Fatalf("Closing clone closed inherited mount: %v", err)
+	}
+
+	if err := billing.Mount("app", container); err == nil {
+		t.Fatalf("Mounted container mounting this container")
+	}
+	nested := NewContainer().(*defaultContainer)
+	if err := nested.Mount("app", container); err != nil {
+		t.Fatalf("Unable to mount container: %s", err)
+	}
+	if err := billing.Mount("nested", nested); err == nil {
+		t.Fatalf("Mounted container mounting this container transitively")
+	}
+}
@@ -0,0 +1,167 @@
+package godi
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// BindOption configures a single binding of Container.Bind or
+// Container.BindSingleton.
+type BindOption func(o *bindOptions)
+
+type bindOptions struct {
+	replace   bool
+	eager     bool
+	scoped    bool
+	qualifier string
+	tags      []string
+	dependsOn []string
+	typ       reflect.Type
+	primary   bool
+	ifAbsent  bool
+	timeout   time.Duration
+	module    string
+	private   bool
+	priority  int
+	phase     string
+}
+
+func newBindOptions(options []BindOption) bindOptions {
+	var o bindOptions
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+// apply configures the binding b according to the options.
+func (o bindOptions) apply(b *binding) error {
+	if o.eager && !b.singleton {
+		return errors.New("only singletons can be constructed eagerly")
+	}
+	if o.scoped && b.singleton {
+		return errors.New("singletons can't be scoped")
+	}
+	if o.phase != "" && !b.singleton {
+		return errors.New("only singletons can be assigned a phase")
+	}
+	b.eager = o.eager || o.phase != ""
+	b.scoped = o.scoped
+	b.qualifier = o.qualifier
+	b.dependsOn = o.dependsOn
+	b.typ = o.typ
+	b.primary = o.primary
+	b.initTimeout = o.timeout
+	b.module = o.module
+	b.private = o.private
+	b.priority = o.priority
+	b.phase = o.phase
+	for _, tag := range o.tags {
+		if !hasTag(b.tags, tag) {
+			b.tags = append(b.tags, tag)
+		}
+	}
+	return nil
+}
+
+// Replace allows the binding to overwrite an existing binding of the same
+// name, e.g. to override a default provided by a framework. Without it,
+// binding a name twice fails. Replacing is only possible before the
+// Container is locked.
+func Replace() BindOption {
+	return func(o *bindOptions) {
+		o.replace = true
+	}
+}
+
+// Eager constructs a singleton, when the Container is locked, instead of
+// on its first request. Failed constructions are logged and retried on
+// the first request.
+func Eager() BindOption {
+	return func(o *bindOptions) {
+		o.eager = true
+	}
+}
+
+// Scoped constructs the dependency once per Scope. All requests within
+// the same Scope receive the same instance, while requests outside of
+// any Scope fail.
+func Scoped() BindOption {
+	return func(o *bindOptions) {
+		o.scoped = true
+	}
+}
+
+// Tags tags the binding, like Container.Tag does.
+func Tags(tags ...string) BindOption {
+	return func(o *bindOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// DependsOn declares dependencies, which are resolved before the binding
+// is constructed, even if its binder doesn't resolve them itself. This
+// orders dependencies with side effects, like database migrations.
+func DependsOn(names ...string) BindOption {
+	return func(o *bindOptions) {
+		o.dependsOn = append(o.dependsOn, names...)
+	}
+}
+
+// Qualifier attaches a qualifier to the binding, distinguishing multiple
+// variants of the same dependency, like "primary" and "replica". The
+// binding is bound under the QualifiedName of its name and qualifier, so
+// all variants coexist and can be selected through ResolveQualified.
+func Qualifier(qualifier string) BindOption {
+	return func(o *bindOptions) {
+		o.qualifier = qualifier
+	}
+}
+
+// DeclareType declares the type of the values constructed by the binding,
+// allowing it to be discovered through FindImplementations without
+// constructing it. BindConstructorAuto and BindTypeOf declare the type
+// automatically.
+func DeclareType(t reflect.Type) BindOption {
+	return func(o *bindOptions) {
+		o.typ = t
+	}
+}
+
+// Primary marks the binding as the one to pick, when multiple bindings
+// implement the same interface or share the same tag, see
+// ResolveImplementation and ResolveTagged.
+func Primary() BindOption {
+	return func(o *bindOptions) {
+		o.primary = true
+	}
+}
+
+// InitTimeout limits the time the construction of the dependency may take.
+// A construction exceeding it fails with an error naming the dependency,
+// instead of blocking the resolution forever. It overrides the default
+// timeout of the Container configured through WithInitTimeout.
+func InitTimeout(timeout time.Duration) BindOption {
+	return func(o *bindOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Priority orders the binding within the groups of its tags. ResolveGroup
+// returns members of higher priority first. Members without a priority
+// have priority 0.
+func Priority(priority int) BindOption {
+	return func(o *bindOptions) {
+		o.priority = priority
+	}
+}
+
+// Phase assigns the singleton to the named startup phase declared through
+// WithPhases, constructing it eagerly within that phase, see
+// Warmer.Warmup.
+func Phase(name string) BindOption {
+	return func(o *bindOptions) {
+		o.phase = name
+	}
+}
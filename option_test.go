@@ -0,0 +1,137 @@
+package godi
+
+import (
+	"testing"
+)
+
+func TestReplace(t *testing.T) {
+	container := NewContainer()
+	container.MustBindSingleton("mailer", func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	})
+	old := MustResolve[*closeRecorder]("mailer", container.Resolver())
+	if err := container.Bind("mailer", func(resolver ResolverFunc) any {
+		return "smtp"
+	}); err == nil {
+		t.Fatalf("Overwrote binding without Replace")
+	}
+	if err := container.Bind("mailer", func(resolver ResolverFunc) any {
+		return "smtp"
+	}, Replace()); err != nil {
+		t.Fatalf("Unable to replace binding: %s", err)
+	}
+	if MustResolve[string]("mailer", container.Resolver()) != "smtp" {
+		t.Fatalf("Binding not replaced")
+	}
+	if !old.closed {
+		t.Fatalf("Replaced singleton instance not closed")
+	}
+	container.Lock()
+	if err := container.Bind("mailer", nil, Replace()); err == nil {
+		t.Fatalf("Replaced binding of locked container")
+	}
+}
+
+func TestEager(t *testing.T) {
+	container := NewContainer()
+	var constructed bool
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		constructed = true
+		return "db"
+	}, Eager())
+	if err := container.Bind("handler", nil, Eager()); err == nil {
+		t.Fatalf("Bound instanced dependency eagerly")
+	}
+	if constructed {
+		t.Fatalf("Eager singleton constructed before lock")
+	}
+	container.Lock()
+	if !constructed {
+		t.Fatalf("Eager singleton not constructed on lock")
+	}
+}
+
+func TestScoped(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var count int
+	container.MustBind("session", func(resolver ResolverFunc) any {
+		count++
+		return &count
+	}, Scoped())
+	if err := container.BindSingleton("cache", nil, Scoped()); err == nil {
+		t.Fatalf("Bound scoped singleton")
+	}
+	if _, err := container.Resolver()("session"); err == nil {
+		t.Fatalf("Resolved scoped dependency outside of a scope")
+	}
+	if info, _ := container.Info("session"); info.Kind != KindScoped {
+		t.Fatalf("Unexpected kind of scoped dependency %s", info.Kind)
+	}
+
+	scope := container.NewScope()
+	MustResolve[*int]("session", scope.Resolver())
+	MustResolve[*int]("session", scope.Resolver())
+	if count != 1 {
+		t.Fatalf("Scoped dependency constructed %d times within one scope", count)
+	}
+	MustResolve[*int]("session", container.NewScope().Resolver())
+	if count != 2 {
+		t.Fatalf("Scoped dependency not constructed per scope")
+	}
+	_ = scope.Close()
+	if _, err := scope.Resolver()("session"); err == nil {
+		t.Fatalf("Resolved scoped dependency from closed scope")
+	}
+}
+
+func TestDependsOn(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var migrated bool
+	container.MustBindSingleton("migrations", func(resolver ResolverFunc) any {
+		migrated = true
+		return true
+	})
+	container.MustBind("repository", func(resolver ResolverFunc) any {
+		if !migrated {
+			t.Fatalf("Repository constructed before its dependencies")
+		}
+		return "repository"
+	}, DependsOn("migrations"), Tags("storage"))
+	container.MustBind("broken", func(resolver ResolverFunc) any {
+		return "broken"
+	}, DependsOn("missing"))
+
+	MustResolve[string]("repository", container.Resolver())
+	if _, err := container.Resolver()("broken"); err == nil {
+		t.Fatalf("Resolved dependency with missing dependency")
+	}
+	info, _ := container.Info("repository")
+	if len(info.DependsOn) != 1 || len(info.Tags) != 1 {
+		t.Fatalf("Options not reported by info: %v", info)
+	}
+}
+
+func TestDefaultContainer_BindIfAbsent(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("mailer", func(resolver ResolverFunc) any {
+		return "application"
+	})
+	bound, err := container.BindIfAbsent("mailer", func(resolver ResolverFunc) any {
+		return "default"
+	})
+	if err != nil || bound {
+		t.Fatalf("Bound default over existing binding: %v", err)
+	}
+	if MustResolve[string]("mailer", container.Resolver()) != "application" {
+		t.Fatalf("Default replaced application binding")
+	}
+	if bound, err = container.BindIfAbsent("cache", func(resolver ResolverFunc) any {
+		return "default"
+	}); err != nil || !bound {
+		t.Fatalf("Unable to bind default: %v", err)
+	}
+	container.Lock()
+	if _, err = container.BindIfAbsent("queue", nil); err == nil {
+		t.Fatalf("Bound default to locked container")
+	}
+}
@@ -0,0 +1,62 @@
+package godi
+
+import (
+	"errors"
+)
+
+// Optional holds a soft dependency, which may legitimately be absent, like
+// a metrics exporter, which is only bound in some deployments. The zero
+// Optional is absent.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// IsPresent reports, whether the dependency was bound.
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+// Get returns the value of the dependency and reports, whether it was
+// bound. The zero value of T is returned for absent dependencies.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// OrElse returns the value of the dependency, or fallback, if it is absent.
+func (o Optional[T]) OrElse(fallback T) T {
+	if !o.present {
+		return fallback
+	}
+	return o.value
+}
+
+// ResolveOptional resolves a dependency by its name like Resolve does, but
+// reports a name, which is not bound, as an absent Optional instead of an
+// error. All other failures, like a failed construction, a missing
+// dependency of the bound service or a type mismatch, are still returned
+// as error, so "not configured" can't be mistaken for "broken".
+func ResolveOptional[T any](name string, resolver ResolverFunc) (Optional[T], error) {
+	t, err := resolver(name)
+	if err != nil {
+		if missing(err) {
+			return Optional[T]{}, nil
+		}
+		return Optional[T]{}, err
+	}
+	v, ok := t.(T)
+	if !ok {
+		return Optional[T]{}, mismatch[T](name, t)
+	}
+	return Optional[T]{value: v, present: true}, nil
+}
+
+// missing reports, whether err reports the resolved name itself as not
+// bound, rather than a failure of one of its dependencies.
+func missing(err error) bool {
+	var re *ResolutionError
+	if errors.As(err, &re) {
+		return re.Kind == FailureNotFound
+	}
+	return errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,38 @@
+package godi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveOptional(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("exporter", func(resolver ResolverFunc) any {
+		return "exporter"
+	})
+	container.MustBind("broken", nil, DependsOn("missing"))
+	resolver := container.Resolver()
+
+	exporter, err := ResolveOptional[string]("exporter", resolver)
+	if err != nil || !exporter.IsPresent() {
+		t.Fatalf("Bound dependency reported absent: %v", err)
+	}
+	if v, ok := exporter.Get(); !ok || v != "exporter" {
+		t.Fatalf("Unexpected value %s", v)
+	}
+
+	absent, err := ResolveOptional[string]("missing", resolver)
+	if err != nil || absent.IsPresent() {
+		t.Fatalf("Unbound dependency not reported absent: %v", err)
+	}
+	if v := absent.OrElse("fallback"); v != "fallback" {
+		t.Fatalf("Fallback of absent dependency not returned: %s", v)
+	}
+
+	if _, err := ResolveOptional[string]("broken", resolver); err == nil {
+		t.Fatalf("Missing dependency of bound service reported absent")
+	}
+	if _, err := ResolveOptional[int]("exporter", resolver); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Type mismatch not reported: %v", err)
+	}
+}
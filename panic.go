@@ -0,0 +1,49 @@
+package godi
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// PanicInfo describes a failure passed to the panic handler.
+type PanicInfo struct {
+	// Name is the dependency, which failed to be bound or resolved.
+	Name string
+	// Chain lists the dependencies, whose construction resolved the failed
+	// dependency, outermost first. It is empty for failed bindings and
+	// resolutions outside of any binder.
+	Chain []string
+	// Err is the error, the call panics with.
+	Err error
+}
+
+var panicHandler atomic.Pointer[func(info PanicInfo)]
+
+// SetPanicHandler registers a handler, which is passed every failing
+// MustBind, MustBindSingleton, MustResolve and MustResolveAll call right
+// before it panics. MustResolveAll reports every failed dependency on its
+// own. Applications may use it to route these failures through their
+// crash reporting. Passing nil removes the handler.
+func SetPanicHandler(handler func(info PanicInfo)) {
+	if handler == nil {
+		panicHandler.Store(nil)
+		return
+	}
+	panicHandler.Store(&handler)
+}
+
+// ReportPanic passes the failure of the named dependency to the registered
+// panic handler, if any. Packages providing their own Must helpers on top
+// of godi call it right before they panic.
+func ReportPanic(name string, err error) {
+	handler := panicHandler.Load()
+	if handler == nil {
+		return
+	}
+	info := PanicInfo{Name: name, Err: err}
+	var re *ResolutionError
+	if errors.As(err, &re) {
+		info.Chain = append([]string(nil), re.Chain...)
+	}
+	(*handler)(info)
+}
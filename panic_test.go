@@ -0,0 +1,96 @@
+package godi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSetPanicHandler(t *testing.T) {
+	var reported []PanicInfo
+	SetPanicHandler(func(info PanicInfo) {
+		reported = append(reported, info)
+	})
+	defer SetPanicHandler(nil)
+
+	container := NewContainer()
+	container.Lock()
+	calls := []func(){
+		func() {
+			container.MustBind("locked", nil)
+		},
+		func() {
+			container.MustBindSingleton("locked", nil)
+		},
+		func() {
+			MustResolve[int]("missing", container.Resolver())
+		},
+		func() {
+			MustResolveAll(container.Resolver(), "missing")
+		},
+	}
+	for i, call := range calls {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatalf("Call %d did not panic", i)
+				}
+			}()
+			call()
+		}()
+	}
+	if len(reported) != len(calls) {
+		t.Fatalf("Expected %d reported panics, got %d", len(calls), len(reported))
+	}
+	if !errors.Is(reported[2].Err, ErrNotFound) || reported[2].Name != "missing" || reported[0].Name != "locked" {
+		t.Fatalf("Unexpected reported failure %+v", reported[2])
+	}
+
+	SetPanicHandler(nil)
+	func() {
+		defer func() {
+			_ = recover()
+		}()
+		MustResolve[int]("missing", container.Resolver())
+	}()
+	if len(reported) != len(calls) {
+		t.Fatalf("Removed panic handler still called")
+	}
+}
+
+func TestSetPanicHandler_Chain(t *testing.T) {
+	var reported []PanicInfo
+	SetPanicHandler(func(info PanicInfo) {
+		reported = append(reported, info)
+	})
+	defer SetPanicHandler(nil)
+
+	container := NewContainer()
+	container.MustBind("handler", func(resolver ResolverFunc) any {
+		return MustResolve[string]("repository", resolver)
+	})
+	container.MustBind("repository", func(resolver ResolverFunc) any {
+		return MustResolve[string]("missing", resolver)
+	})
+	for _, call := range []func(){
+		func() {
+			MustResolve[string]("handler", container.Resolver())
+		},
+		func() {
+			MustResolveAll(container.Resolver(), "config", "secrets")
+		},
+	} {
+		func() {
+			defer func() {
+				_ = recover()
+			}()
+			call()
+		}()
+	}
+	if len(reported) != 3 || reported[0].Name != "missing" || !reflect.DeepEqual(reported[0].Chain, []string{"handler", "repository"}) {
+		t.Fatalf("Unexpected reported failures %+v", reported)
+	}
+	if reported[1].Name != "config" || reported[2].Name != "secrets" || len(reported[2].Chain) != 0 {
+		t.Fatalf("Failed dependencies of MustResolveAll not reported on their own: %+v", reported)
+	}
+}
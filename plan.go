@@ -0,0 +1,87 @@
+package godi
+
+import (
+	"context"
+	"fmt"
+)
+
+// plans maps the names of services to their resolution plan, the names of
+// all singletons they transitively depend on, ordered so every singleton
+// comes after its own dependencies.
+type plans map[string][]string
+
+type planKey struct{}
+
+// compilePlans derives the resolution plans of all services from their
+// declared dependencies and the dependencies recorded so far. Services
+// depending on a cycle get no plan, so their resolution reports the cycle.
+func (d *defaultContainer) compilePlans() plans {
+	compiled := make(plans)
+	d.table.eachService(func(name string, _ *binding) {
+		var order []string
+		state := make(map[string]int)
+		cyclic := false
+		var visit func(current string)
+		visit = func(current string) {
+			switch state[current] {
+			case 1:
+				cyclic = true
+				return
+			case 2:
+				return
+			}
+			state[current] = 1
+			b, ok := d.planned(current)
+			if ok {
+				for _, dependency := range b.dependsOn {
+					visit(dependency)
+				}
+			}
+			for _, dependency := range d.dependencies(current) {
+				visit(dependency)
+			}
+			state[current] = 2
+			if ok && current != name && b.singleton && !b.scoped {
+				order = append(order, current)
+			}
+		}
+		visit(name)
+		if !cyclic && len(order) > 0 {
+			compiled[name] = order
+		}
+	})
+	return compiled
+}
+
+// planned returns the binding resolved by the given name, following
+// aliases. The caller must hold the mutex of the Container.
+func (d *defaultContainer) planned(name string) (*binding, bool) {
+	chain := d.aliasChain(name)
+	return d.table.service(chain[len(chain)-1])
+}
+
+// executePlan constructs the singletons of the resolution plan of the
+// named service in a flat loop, so the nested resolutions performed by
+// its binder only hit cached instances. Resolutions within a plan don't
+// execute plans themselves and aren't recorded as dependencies.
+func (d *defaultContainer) executePlan(ctx context.Context, name string) error {
+	if ctx.Value(planKey{}) != nil {
+		return nil
+	}
+	compiled := d.plans.Load()
+	if compiled == nil {
+		return nil
+	}
+	plan := (*compiled)[name]
+	if len(plan) == 0 {
+		return nil
+	}
+	ctx = context.WithValue(withoutScope(ctx), planKey{}, true)
+	ctx = context.WithValue(ctx, dependentKey{}, (*dependent)(nil))
+	for _, dependency := range plan {
+		if _, err := d.resolve(ctx, dependency); err != nil {
+			return fmt.Errorf("unable to resolve dependency %s of %s service: %w", dependency, name, err)
+		}
+	}
+	return nil
+}
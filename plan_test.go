@@ -0,0 +1,90 @@
+package godi
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// bindChain binds a chain of singletons, in which every singleton resolves
+// its predecessor, and returns the name of the last one.
+func bindChain(c Container, length int) string {
+	for i := 0; i < length; i++ {
+		i := i
+		c.MustBindSingleton(fmt.Sprintf("s%d", i), func(resolver ResolverFunc) any {
+			if i == 0 {
+				return 0
+			}
+			return MustResolve[int](fmt.Sprintf("s%d", i-1), resolver) + 1
+		})
+	}
+	return fmt.Sprintf("s%d", length-1)
+}
+
+func TestDefaultContainer_Lock_Plans(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var order []string
+	record := func(name string) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			order = append(order, name)
+			return name
+		}
+	}
+	container.MustBindSingleton("db", record("db"))
+	container.MustBindSingleton("migrations", record("migrations"), DependsOn("db"))
+	container.MustBind("repository", func(resolver ResolverFunc) any {
+		return MustResolve[string]("store", resolver)
+	})
+	container.MustBindSingleton("cache", record("cache"))
+	_ = container.Alias("store", "cache")
+	container.MustBindSingleton("service", func(resolver ResolverFunc) any {
+		MustResolve[string]("repository", resolver)
+		return "service"
+	}, DependsOn("migrations"))
+
+	MustResolve[string]("service", container.Resolver())
+	container.Lock()
+	plan := (*container.plans.Load())["service"]
+	if !reflect.DeepEqual(plan, []string{"db", "migrations", "cache"}) {
+		t.Fatalf("Unexpected resolution plan %v", plan)
+	}
+
+	clone := container.Clone().(*defaultContainer)
+	order = nil
+	MustResolve[string]("service", clone.Resolver())
+	if !reflect.DeepEqual(order, []string{"db", "migrations", "cache"}) {
+		t.Fatalf("Plan not executed in order, got %v", order)
+	}
+	if deps := clone.dependencies("service"); !reflect.DeepEqual(deps, []string{"repository"}) {
+		t.Fatalf("Plan recorded as dependencies: %v", deps)
+	}
+}
+
+func TestDefaultContainer_Lock_Plans_Cycle(t *testing.T) {
+	container := NewContainer()
+	container.MustBindSingleton("a", nil, DependsOn("b"))
+	container.MustBindSingleton("b", nil, DependsOn("a"))
+	container.MustBindSingleton("c", nil, DependsOn("a"))
+	container.Lock()
+	if compiled := *container.(*defaultContainer).plans.Load(); len(compiled) > 0 {
+		t.Fatalf("Compiled plans for cyclic dependencies: %v", compiled)
+	}
+}
+
+func BenchmarkResolve_DeepGraph(b *testing.B) {
+	for _, planned := range []bool{false, true} {
+		b.Run(fmt.Sprintf("planned=%t", planned), func(b *testing.B) {
+			container := NewContainer().(*defaultContainer)
+			name := bindChain(container, 64)
+			MustResolve[int](name, container.Resolver())
+			if planned {
+				container.Lock()
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				MustResolve[int](name, container.Clone().Resolver())
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrPoolClosed is returned by Pool.Acquire, once the Pool was closed.
+var ErrPoolClosed = errors.New("pool closed")
+
+// Pool maintains a limited number of instances of a dependency bound
+// through ExtendedBinder.BindPool. Instances are constructed lazily, once no
+// idle instance is available, until the size of the Pool is reached.
+// Afterwards, Acquire waits until an instance is released.
+type Pool struct {
+	name   string
+	create func(ctx context.Context) (any, error)
+	slots  chan struct{}
+	idle   chan any
+	done   chan struct{}
+	mu     sync.Mutex
+	closed bool
+}
+
+func newPool(name string, size int, create func(ctx context.Context) (any, error)) *Pool {
+	return &Pool{
+		name:   name,
+		create: create,
+		slots:  make(chan struct{}, size),
+		idle:   make(chan any, size),
+		done:   make(chan struct{}),
+	}
+}
+
+// Acquire checks out an instance of the Pool. It blocks until an instance
+// is available, the given context is done or the Pool is closed. New
+// instances are constructed within the given context. Every acquired
+// instance must be returned through Release. Once the Pool was closed,
+// Acquire fails with ErrPoolClosed.
+func (p *Pool) Acquire(ctx context.Context) (any, error) {
+	select {
+	case <-p.done:
+		return nil, p.closedError()
+	default:
+	}
+	select {
+	case value := <-p.idle:
+		return value, nil
+	default:
+	}
+	select {
+	case value := <-p.idle:
+		return value, nil
+	case p.slots <- struct{}{}:
+		value, err := p.create(ctx)
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+		return value, nil
+	case <-p.done:
+		return nil, p.closedError()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *Pool) closedError() error {
+	return fmt.Errorf("unable to acquire %s instance: %w", p.name, ErrPoolClosed)
+}
+
+// Release checks an acquired instance back into the Pool. Instances
+// released to a closed Pool and surplus instances, which don't fit into
+// the Pool, like ones released twice, are closed, if they implement
+// io.Closer.
+func (p *Pool) Release(value any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		select {
+		case p.idle <- value:
+			return
+		default:
+		}
+	}
+	if closer, ok := value.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// Close closes all idle instances of the Pool implementing io.Closer.
+// Instances released afterwards are closed on their release. Pending and
+// later calls of Acquire fail with ErrPoolClosed.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	var errs []error
+	for {
+		select {
+		case value := <-p.idle:
+			if closer, ok := value.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// Acquire is a helper function to acquire an instance of the given type
+// from a Pool. The instance is released again, if it can't be converted.
+func Acquire[T any](ctx context.Context, pool *Pool) (T, error) {
+	value, err := pool.Acquire(ctx)
+	if err != nil {
+		var res T
+		return res, err
+	}
+	v, ok := value.(T)
+	if !ok {
+		pool.Release(value)
+		return v, mismatch[T](pool.name, value)
+	}
+	return v, nil
+}
+
+// poolFactory returns a factory constructing the named Pool of the given
+// size, whose instances are constructed by the given binder. Instances
+// resolve their dependencies within the context passed to Acquire, as the
+// dependent of the Pool.
+func poolFactory(name string, size int, binder BinderFunc) factory {
+	if binder == nil {
+		return nil
+	}
+	return func(ctx context.Context, _ ResolverFunc) (any, error) {
+		resolver := resolverFrom(ctx)
+		owner := dependentFrom(ctx)
+		return newPool(name, size, func(ctx context.Context) (any, error) {
+			return binder(resolver(context.WithValue(ctx, dependentKey{}, owner))), nil
+		}), nil
+	}
+}
+
+func (d *defaultContainer) BindPool(name string, size int, binder BinderFunc) error {
+	if size <= 0 {
+		return errors.New(fmt.Sprintf("size of pool %s must be positive", name))
+	}
+	b := newBinding(poolFactory(name, size, binder), true)
+	b.poolSize = size
+	return d.bind(name, b)
+}
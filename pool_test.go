@@ -0,0 +1,144 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDefaultContainer_BindPool(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	var created int
+	err := container.BindPool("interpreter", 2, func(resolver ResolverFunc) any {
+		created++
+		return &closeRecorder{}
+	})
+	if err != nil {
+		t.Fatalf("Unable to bind pool %s: %s", "interpreter", err)
+	}
+	if err = container.BindPool("empty", 0, nil); err == nil {
+		t.Fatalf("Bound pool without size")
+	}
+	if info, _ := container.Info("interpreter"); info.Kind != KindPool {
+		t.Fatalf("Unexpected kind of pool %s", info.Kind)
+	}
+
+	pool := MustResolve[*Pool]("interpreter", container.Resolver())
+	if pool != MustResolve[*Pool]("interpreter", container.Resolver()) {
+		t.Fatalf("Pool not shared between resolutions")
+	}
+	ctx := context.Background()
+	a, err := Acquire[*closeRecorder](ctx, pool)
+	if err != nil {
+		t.Fatalf("Unable to acquire instance: %s", err)
+	}
+	b, _ := Acquire[*closeRecorder](ctx, pool)
+	if a == b || created != 2 {
+		t.Fatalf("Expected two distinct instances, created %d", created)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if _, err = pool.Acquire(timeout); err == nil {
+		t.Fatalf("Acquired more instances than the pool size")
+	}
+
+	pool.Release(a)
+	c, _ := Acquire[*closeRecorder](ctx, pool)
+	if c != a || created != 2 {
+		t.Fatalf("Released instance not reused")
+	}
+
+	pool.Release(c)
+	if err = pool.Close(); err != nil {
+		t.Fatalf("Unable to close pool: %s", err)
+	}
+	if !c.closed {
+		t.Fatalf("Idle instance not closed with the pool")
+	}
+	pool.Release(b)
+	if !b.closed {
+		t.Fatalf("Instance released to closed pool not closed")
+	}
+}
+
+func TestPool_Release_Surplus(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	if err := container.BindPool("interpreter", 1, func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	}); err != nil {
+		t.Fatalf("Unable to bind pool %s: %s", "interpreter", err)
+	}
+	pool := MustResolve[*Pool]("interpreter", container.Resolver())
+	ctx := context.Background()
+	if _, err := Acquire[string](ctx, pool); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Conversion failure not reported as ErrTypeMismatch: %v", err)
+	}
+	a, _ := Acquire[*closeRecorder](ctx, pool)
+	pool.Release(a)
+	surplus := &closeRecorder{}
+	pool.Release(surplus)
+	if !surplus.closed || a.closed {
+		t.Fatalf("Surplus instance not closed on release")
+	}
+	if err := pool.Close(); err != nil || !a.closed {
+		t.Fatalf("Unable to close pool: %v", err)
+	}
+}
+
+func TestPool_Close(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	if err := container.BindPool("interpreter", 1, func(resolver ResolverFunc) any {
+		return &closeRecorder{}
+	}); err != nil {
+		t.Fatalf("Unable to bind pool %s: %s", "interpreter", err)
+	}
+	pool := MustResolve[*Pool]("interpreter", container.Resolver())
+	ctx := context.Background()
+	a, _ := pool.Acquire(ctx)
+	pending := make(chan error, 1)
+	go func() {
+		_, err := pool.Acquire(ctx)
+		pending <- err
+	}()
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Unable to close pool: %s", err)
+	}
+	select {
+	case err := <-pending:
+		if !errors.Is(err, ErrPoolClosed) {
+			t.Fatalf("Pending acquisition not failed with ErrPoolClosed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Pending acquisition blocked on closed pool")
+	}
+	pool.Release(a)
+	if _, err := pool.Acquire(ctx); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("Acquired instance of closed pool: %v", err)
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Unable to close pool twice: %s", err)
+	}
+}
+
+func TestPool_Acquire_Context(t *testing.T) {
+	container := NewContainer(WithInitTimeout(time.Second)).(*defaultContainer)
+	if err := container.BindCtx("alive", func(ctx context.Context, resolver ResolverFunc) (any, error) {
+		return ctx.Err() == nil, nil
+	}); err != nil {
+		t.Fatalf("Unable to bind %s: %s", "alive", err)
+	}
+	if err := container.BindPool("conn", 2, func(resolver ResolverFunc) any {
+		return MustResolve[bool]("alive", resolver)
+	}); err != nil {
+		t.Fatalf("Unable to bind pool %s: %s", "conn", err)
+	}
+	pool := MustResolve[*Pool]("conn", container.Resolver())
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if alive, err := Acquire[bool](ctx, pool); err != nil || !alive {
+			t.Fatalf("Instance %d not constructed within the context of Acquire: %v", i, err)
+		}
+	}
+}
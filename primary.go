@@ -0,0 +1,58 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ResolveImplementation resolves the single dependency implementing the
+// interface I, as found by FindImplementations. If multiple dependencies
+// implement I, the one marked through the Primary option is picked.
+// An error listing all candidates is returned, if none or multiple of
+// them are marked.
+func ResolveImplementation[I any](c Container) (I, error) {
+	name, err := selectPrimary(c, "implementation of "+NameOf[I](), FindImplementations[I](c))
+	if err != nil {
+		var res I
+		return res, err
+	}
+	return Resolve[I](name, c.Resolver())
+}
+
+// ResolveTagged resolves the single dependency tagged with the given tag,
+// picking the primary one among multiple candidates like
+// ResolveImplementation does.
+func ResolveTagged[T any](c Container, tag string) (T, error) {
+	name, err := selectPrimary(c, "dependency tagged "+tag, c.Tagged(tag))
+	if err != nil {
+		var res T
+		return res, err
+	}
+	return Resolve[T](name, c.Resolver())
+}
+
+// selectPrimary selects the name to resolve among the given candidates.
+func selectPrimary(c Container, description string, candidates []string) (string, error) {
+	switch len(candidates) {
+	case 0:
+		return "", errors.New(fmt.Sprintf("no %s found in container", description))
+	case 1:
+		return candidates[0], nil
+	}
+	var primaries []string
+	if introspector, ok := AsIntrospector(c); ok {
+		for _, name := range candidates {
+			if info, err := introspector.Info(name); err == nil && info.Primary {
+				primaries = append(primaries, name)
+			}
+		}
+	}
+	switch len(primaries) {
+	case 0:
+		return "", errors.New(fmt.Sprintf("ambiguous %s. none of %s is marked as primary", description, strings.Join(candidates, ", ")))
+	case 1:
+		return primaries[0], nil
+	}
+	return "", errors.New(fmt.Sprintf("ambiguous %s. %s are all marked as primary", description, strings.Join(primaries, ", ")))
+}
@@ -0,0 +1,62 @@
+package godi
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveImplementation(t *testing.T) {
+	container := NewContainer()
+	declared := DeclareType(reflect.TypeOf(&bytes.Buffer{}))
+	container.MustBind("buffer", func(resolver ResolverFunc) any {
+		return bytes.NewBufferString("buffer")
+	}, declared)
+	if r, err := ResolveImplementation[io.Reader](container); err != nil || r == nil {
+		t.Fatalf("Unable to resolve single implementation: %v", err)
+	}
+	if _, err := ResolveImplementation[io.Closer](container); err == nil {
+		t.Fatalf("Resolved missing implementation")
+	}
+
+	container.MustBind("other", func(resolver ResolverFunc) any {
+		return bytes.NewBufferString("other")
+	}, declared)
+	_, err := ResolveImplementation[io.Reader](container)
+	if err == nil || !strings.Contains(err.Error(), "buffer, other") {
+		t.Fatalf("Expected ambiguity error listing candidates, got %v", err)
+	}
+
+	container.MustBind("primary", func(resolver ResolverFunc) any {
+		return bytes.NewBufferString("primary")
+	}, declared, Primary())
+	r, err := ResolveImplementation[io.Reader](container)
+	if err != nil || r.(*bytes.Buffer).String() != "primary" {
+		t.Fatalf("Primary implementation not picked: %v", err)
+	}
+
+	container.MustBind("second", func(resolver ResolverFunc) any {
+		return bytes.NewBufferString("second")
+	}, declared, Primary())
+	if _, err = ResolveImplementation[io.Reader](container); err == nil {
+		t.Fatalf("Resolved implementation with multiple primaries")
+	}
+}
+
+func TestResolveTagged(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("smtp", func(resolver ResolverFunc) any {
+		return "smtp"
+	}, Tags("mailer"))
+	container.MustBind("ses", func(resolver ResolverFunc) any {
+		return "ses"
+	}, Tags("mailer"), Primary())
+	if v, err := ResolveTagged[string](container, "mailer"); err != nil || v != "ses" {
+		t.Fatalf("Primary tagged dependency not picked: %v", err)
+	}
+	if _, err := ResolveTagged[string](container, "cache"); err == nil {
+		t.Fatalf("Resolved missing tag")
+	}
+}
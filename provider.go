@@ -0,0 +1,205 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ProviderSet is a reusable bundle of bindings, aliases and tags, which
+// library packages may declare to wire their dependencies into any
+// Container. ProviderSets are immutable values, every method returns a new
+// ProviderSet, so sets can be composed freely without affecting each other.
+//
+//	var Set = godi.NewProviderSet().
+//		BindSingleton("db", newDB).
+//		Bind("repository", newRepository).
+//		Alias("store", "repository").
+//		Tag("repository", "storage")
+//
+// A ProviderSet is applied to a Container through Composer.Apply.
+type ProviderSet struct {
+	providers []provider
+	aliases   []providerAlias
+	tags      []providerTag
+}
+
+type provider struct {
+	name      string
+	binder    BinderFunc
+	singleton bool
+	options   []BindOption
+}
+
+type providerAlias struct {
+	name   string
+	target string
+}
+
+type providerTag struct {
+	name string
+	tags []string
+}
+
+// NewProviderSet creates a ProviderSet including all entries of the given
+// sets.
+func NewProviderSet(sets ...ProviderSet) ProviderSet {
+	var p ProviderSet
+	return p.Include(sets...)
+}
+
+// Include returns a ProviderSet additionally including all entries of the
+// given sets.
+func (p ProviderSet) Include(sets ...ProviderSet) ProviderSet {
+	for _, set := range sets {
+		p.providers = append(slices.Clip(p.providers), set.providers...)
+		p.aliases = append(slices.Clip(p.aliases), set.aliases...)
+		p.tags = append(slices.Clip(p.tags), set.tags...)
+	}
+	return p
+}
+
+// Bind returns a ProviderSet additionally binding an instanced dependency.
+func (p ProviderSet) Bind(name string, binder BinderFunc, options ...BindOption) ProviderSet {
+	p.providers = append(slices.Clip(p.providers), provider{name: name, binder: binder, options: options})
+	return p
+}
+
+// BindSingleton returns a ProviderSet additionally binding a singleton
+// dependency.
+func (p ProviderSet) BindSingleton(name string, binder BinderFunc, options ...BindOption) ProviderSet {
+	p.providers = append(slices.Clip(p.providers), provider{name: name, binder: binder, singleton: true, options: options})
+	return p
+}
+
+// Alias returns a ProviderSet additionally aliasing target as name.
+func (p ProviderSet) Alias(name, target string) ProviderSet {
+	p.aliases = append(slices.Clip(p.aliases), providerAlias{name: name, target: target})
+	return p
+}
+
+// Tag returns a ProviderSet additionally adding the named dependency to
+// the groups of the given tags.
+func (p ProviderSet) Tag(name string, tags ...string) ProviderSet {
+	p.tags = append(slices.Clip(p.tags), providerTag{name: name, tags: tags})
+	return p
+}
+
+// Names returns the names of all bindings and aliases of the ProviderSet
+// in order of their declaration.
+func (p ProviderSet) Names() []string {
+	names := make([]string, 0, len(p.providers)+len(p.aliases))
+	for _, entry := range p.providers {
+		names = append(names, entry.name)
+	}
+	for _, entry := range p.aliases {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// validate checks all entries of the ProviderSet against the Container
+// like binding, aliasing and tagging them would, so Apply either binds all
+// entries or none. It reports all names provided more than once, names
+// already bound to the Container, unless their bindings are marked to
+// Replace them, invalid names and options, alias cycles and tags of names,
+// which are neither bound nor provided.
+func (p ProviderSet) validate(d *defaultContainer) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.frozen {
+		return ErrFrozen
+	}
+	if d.locked.Load() {
+		return errors.New("service container locked. no more services can be bound")
+	}
+	var errs []error
+	seen := make(map[string]bool, len(p.providers)+len(p.aliases))
+	check := func(name string, replace bool) {
+		if seen[name] {
+			errs = append(errs, errors.New(fmt.Sprintf("%s service provided more than once", name)))
+		}
+		seen[name] = true
+		_, bound := d.table.service(name)
+		_, aliased := d.table.alias(name)
+		if aliased || bound && !replace {
+			errs = append(errs, errors.New(fmt.Sprintf("service with name %s already bound", name)))
+		}
+	}
+	services := make(map[string]bool, len(p.providers))
+	for _, entry := range p.providers {
+		o := newBindOptions(entry.options)
+		b := newBinding(binderFactory(entry.binder), entry.singleton)
+		if err := o.apply(b); err != nil {
+			errs = append(errs, errors.New(fmt.Sprintf("unable to bind %s service: %s", entry.name, err)))
+			continue
+		}
+		name := entry.name
+		if o.qualifier != "" {
+			name = QualifiedName(name, o.qualifier)
+		}
+		check(name, o.replace)
+		if err := d.validate(name, b); err != nil {
+			errs = append(errs, err)
+		}
+		services[name] = true
+	}
+	aliases := make(map[string]string, len(p.aliases))
+	for _, entry := range p.aliases {
+		check(entry.name, false)
+		if err := d.validateName(entry.name); err != nil {
+			errs = append(errs, err)
+		}
+		aliases[entry.name] = entry.target
+	}
+	alias := func(name string) (string, bool) {
+		if target, ok := aliases[name]; ok {
+			return target, true
+		}
+		return d.table.alias(name)
+	}
+	for _, entry := range p.aliases {
+		visited := make(map[string]bool)
+		for next, ok := entry.target, true; ok && !visited[next]; next, ok = alias(next) {
+			if next == entry.name {
+				errs = append(errs, errors.New(fmt.Sprintf("alias %s for %s would create a cycle", entry.name, entry.target)))
+				break
+			}
+			visited[next] = true
+		}
+	}
+	for _, entry := range p.tags {
+		if _, bound := d.table.service(entry.name); !bound && !services[entry.name] {
+			errs = append(errs, &notFoundError{name: entry.name})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *defaultContainer) Apply(set ProviderSet) error {
+	if err := set.validate(d); err != nil {
+		return fmt.Errorf("unable to apply provider set: %w", err)
+	}
+	for _, entry := range set.providers {
+		var err error
+		if entry.singleton {
+			err = d.BindSingleton(entry.name, entry.binder, entry.options...)
+		} else {
+			err = d.Bind(entry.name, entry.binder, entry.options...)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to apply provider set: %w", err)
+		}
+	}
+	for _, entry := range set.aliases {
+		if err := d.Alias(entry.name, entry.target); err != nil {
+			return fmt.Errorf("unable to apply provider set: %w", err)
+		}
+	}
+	for _, entry := range set.tags {
+		if err := d.Tag(entry.name, entry.tags...); err != nil {
+			return fmt.Errorf("unable to apply provider set: %w", err)
+		}
+	}
+	return nil
+}
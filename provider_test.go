@@ -0,0 +1,78 @@
+package godi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultContainer_Apply(t *testing.T) {
+	storage := NewProviderSet().
+		BindSingleton("db", func(resolver ResolverFunc) any {
+			return "db"
+		}).
+		Bind("repository", func(resolver ResolverFunc) any {
+			return "repository of " + MustResolve[string]("db", resolver)
+		}).
+		Alias("store", "repository").
+		Tag("repository", "storage")
+	set := NewProviderSet(storage).Bind("handler", func(resolver ResolverFunc) any {
+		return "handler"
+	})
+	if len(storage.Names()) != 3 {
+		t.Fatalf("Composition modified included set: %v", storage.Names())
+	}
+
+	container := NewContainer().(*defaultContainer)
+	if err := container.Apply(set); err != nil {
+		t.Fatalf("Unable to apply provider set: %s", err)
+	}
+	if v := MustResolve[string]("store", container.Resolver()); v != "repository of db" {
+		t.Fatalf("Unexpected value of aliased dependency %s", v)
+	}
+	if tagged := container.Tagged("storage"); !reflect.DeepEqual(tagged, []string{"repository"}) {
+		t.Fatalf("Tags of provider set not applied: %v", tagged)
+	}
+
+	if err := NewContainer().(*defaultContainer).Apply(NewProviderSet(storage, storage)); err == nil {
+		t.Fatalf("Applied provider set providing names more than once")
+	}
+	conflicting := NewProviderSet().Bind("handler", nil).Bind("fresh", func(resolver ResolverFunc) any {
+		return "fresh"
+	})
+	if err := container.Apply(conflicting); err == nil {
+		t.Fatalf("Applied provider set conflicting with bound names")
+	}
+	if _, err := container.Resolver()("fresh"); err == nil {
+		t.Fatalf("Conflicting provider set applied partially")
+	}
+	replacing := NewProviderSet().Bind("handler", func(resolver ResolverFunc) any {
+		return "replaced"
+	}, Replace())
+	if err := container.Apply(replacing); err != nil {
+		t.Fatalf("Unable to apply replacing provider set: %s", err)
+	}
+	if v := MustResolve[string]("handler", container.Resolver()); v != "replaced" {
+		t.Fatalf("Binding not replaced by provider set")
+	}
+}
+
+func TestDefaultContainer_Apply_Validation(t *testing.T) {
+	binder := func(resolver ResolverFunc) any {
+		return "value"
+	}
+	for name, set := range map[string]ProviderSet{
+		"reserved prefix": NewProviderSet().Bind("handler", binder).Bind("godi.internal", binder),
+		"nil binder":      NewProviderSet().Bind("handler", binder).Bind("repository", nil),
+		"invalid option":  NewProviderSet().Bind("handler", binder).Bind("cache", binder, Eager()),
+		"alias cycle":     NewProviderSet().Bind("handler", binder).Alias("a", "b").Alias("b", "a"),
+		"unknown tag":     NewProviderSet().Bind("handler", binder).Tag("missing", "storage"),
+	} {
+		container := NewContainer(WithStrictMode(), WithReservedPrefixes("godi.")).(*defaultContainer)
+		if err := container.Apply(set); err == nil {
+			t.Fatalf("Applied provider set with %s", name)
+		}
+		if names := container.Names(); len(names) != 1 {
+			t.Fatalf("Provider set with %s applied partially: %v", name, names)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package godi
+
+import (
+	"strings"
+)
+
+// QualifiedName returns the name, under which the variant of a dependency
+// bound with the Qualifier option can be resolved.
+func QualifiedName(name, qualifier string) string {
+	return name + "#" + qualifier
+}
+
+// qualify returns the name, a binding with the given options is bound under.
+func qualify(name string, options []BindOption) string {
+	if o := newBindOptions(options); o.qualifier != "" {
+		return QualifiedName(name, o.qualifier)
+	}
+	return name
+}
+
+// ResolveQualified is a helper function resolving the variant of a
+// dependency selected by the given qualifier, like Resolve does. Combined
+// with NameOf, variants are selected by their type and qualifier.
+func ResolveQualified[T any](name, qualifier string, resolver ResolverFunc) (T, error) {
+	return Resolve[T](QualifiedName(name, qualifier), resolver)
+}
+
+// Qualifiers returns the sorted qualifiers of all variants of the named
+// dependency bound to the given Container. Containers, which don't
+// implement Introspector, have no known variants.
+func Qualifiers(c Container, name string) []string {
+	introspector, ok := AsIntrospector(c)
+	if !ok {
+		return nil
+	}
+	var qualifiers []string
+	for _, bound := range introspector.Names() {
+		if qualifier, ok := strings.CutPrefix(bound, name+"#"); ok {
+			qualifiers = append(qualifiers, qualifier)
+		}
+	}
+	return qualifiers
+}
@@ -0,0 +1,52 @@
+package godi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveQualified(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return "primary"
+	}, Qualifier("primary"))
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return "replica"
+	}, Qualifier("replica"))
+	if err := container.Bind("db", nil, Qualifier("primary")); err == nil {
+		t.Fatalf("Bound qualified variant twice")
+	}
+	resolver := container.Resolver()
+
+	for _, qualifier := range []string{"primary", "replica"} {
+		if v, err := ResolveQualified[string]("db", qualifier, resolver); err != nil || v != qualifier {
+			t.Fatalf("Unable to resolve %s variant: %v", qualifier, err)
+		}
+	}
+	if _, err := ResolveQualified[string]("db", "archive", resolver); err == nil {
+		t.Fatalf("Resolved unknown variant")
+	}
+	if info, _ := container.Info(QualifiedName("db", "replica")); info.Qualifier != "replica" {
+		t.Fatalf("Qualifier not reported by info")
+	}
+	if qualifiers := Qualifiers(container, "db"); !reflect.DeepEqual(qualifiers, []string{"primary", "replica"}) {
+		t.Fatalf("Unexpected qualifiers %v", qualifiers)
+	}
+}
+
+func TestResolveQualified_Type(t *testing.T) {
+	container := NewContainer()
+	name, err := BindConstructorAuto(container, func(resolver ResolverFunc) *typeNameService {
+		return &typeNameService{name: "replica"}
+	}, Qualifier("replica"))
+	if err != nil {
+		t.Fatalf("Unable to bind constructor: %s", err)
+	}
+	if name != QualifiedName(NameOf[*typeNameService](), "replica") {
+		t.Fatalf("Unexpected derived name %s", name)
+	}
+	service, err := ResolveQualified[*typeNameService](NameOf[*typeNameService](), "replica", container.Resolver())
+	if err != nil || service.name != "replica" {
+		t.Fatalf("Unable to resolve variant by type: %v", err)
+	}
+}
@@ -3,8 +3,22 @@ package godi
 import (
 	"errors"
 	"fmt"
+	"reflect"
 )
 
+// TypedResolver resolves dependencies of a single type by their name.
+// Subsystems, which only ever resolve one type, like a registry of codecs,
+// may accept a TypedResolver instead of a ResolverFunc.
+type TypedResolver[T any] func(name string) (T, error)
+
+// AsTyped adapts a ResolverFunc to a TypedResolver, which converts every
+// resolved dependency to the given type like Resolve does.
+func AsTyped[T any](resolver ResolverFunc) TypedResolver[T] {
+	return func(name string) (T, error) {
+		return Resolve[T](name, resolver)
+	}
+}
+
 // MustResolve is a helper function to simplify interaction with a
 // ResolverFunc. MustResolve tries to fetch a dependency by its name
 // and panics, if the dependency can't be converted to the given type
@@ -12,6 +26,7 @@ import (
 func MustResolve[T any](name string, resolver ResolverFunc) T {
 	value, err := Resolve[T](name, resolver)
 	if err != nil {
+		ReportPanic(name, err)
 		panic(err)
 	}
 	return value
@@ -20,7 +35,7 @@ func MustResolve[T any](name string, resolver ResolverFunc) T {
 // Resolve is a helper function to simplify interaction with a ResolverFunc.
 // Resolve tries to fetch a dependency by its name and convert it to the given
 // type. An error is returned if the conversion failed or the dependency could
-// not be found.
+// not be found, matching ErrTypeMismatch or ErrNotFound respectively.
 func Resolve[T any](name string, resolver ResolverFunc) (T, error) {
 	t, err := resolver(name)
 	if err != nil {
@@ -29,7 +44,128 @@ func Resolve[T any](name string, resolver ResolverFunc) (T, error) {
 	}
 	v, ok := t.(T)
 	if !ok {
-		return v, errors.New(fmt.Sprintf("Unable to convert %s to the requested type", name))
+		return v, mismatch[T](name, t)
 	}
 	return v, nil
 }
+
+// ResolveAssignable resolves a dependency by its name like Resolve does, but
+// additionally converts values, whose type is assignable or convertible to
+// the given type, e.g. a dependency bound as time.Duration resolved as
+// int64. Integers are never converted to strings. An error matching
+// ErrTypeMismatch is returned, if the value can't be converted.
+func ResolveAssignable[T any](name string, resolver ResolverFunc) (T, error) {
+	t, err := resolver(name)
+	if err != nil {
+		var res T
+		return res, err
+	}
+	if v, ok := t.(T); ok {
+		return v, nil
+	}
+	var res T
+	target := reflect.TypeOf(&res).Elem()
+	value := reflect.ValueOf(t)
+	if !value.IsValid() {
+		switch target.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return res, nil
+		}
+		return res, mismatch[T](name, t)
+	}
+	if value.Type().AssignableTo(target) {
+		reflect.ValueOf(&res).Elem().Set(value)
+		return res, nil
+	}
+	if !value.CanConvert(target) || target.Kind() == reflect.String && value.CanInt() || target.Kind() == reflect.String && value.CanUint() {
+		return res, mismatch[T](name, t)
+	}
+	reflect.ValueOf(&res).Elem().Set(value.Convert(target))
+	return res, nil
+}
+
+// ResolveInto is a helper function for call sites, where the type of a
+// dependency is only known at runtime. ResolveInto fetches a dependency by
+// its name and assigns it to the variable the given target points to.
+// An error is returned if target is not a non-nil pointer, the dependency
+// could not be found or is not assignable to the variable, which is
+// reported as ErrTypeMismatch.
+func ResolveInto(name string, target any, resolver ResolverFunc) error {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() {
+		return errors.New(fmt.Sprintf("Unable to resolve %s into %T. Target must be a non-nil pointer", name, target))
+	}
+	t, err := resolver(name)
+	if err != nil {
+		return err
+	}
+	elem := ptr.Elem()
+	if t == nil {
+		switch elem.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			elem.SetZero()
+			return nil
+		}
+		return &typeMismatchError{name: name, requested: elem.Type()}
+	}
+	value := reflect.ValueOf(t)
+	if !value.Type().AssignableTo(elem.Type()) {
+		return &typeMismatchError{name: name, requested: elem.Type(), actual: value.Type()}
+	}
+	elem.Set(value)
+	return nil
+}
+
+// Resolve2 is a helper function resolving two dependencies at once, like
+// Resolve does. The first failed resolution is returned as error.
+func Resolve2[A, B any](nameA, nameB string, resolver ResolverFunc) (A, B, error) {
+	var b B
+	a, err := Resolve[A](nameA, resolver)
+	if err != nil {
+		return a, b, err
+	}
+	b, err = Resolve[B](nameB, resolver)
+	return a, b, err
+}
+
+// Resolve3 is a helper function resolving three dependencies at once, like
+// Resolve does. The first failed resolution is returned as error.
+func Resolve3[A, B, C any](nameA, nameB, nameC string, resolver ResolverFunc) (A, B, C, error) {
+	var c C
+	a, b, err := Resolve2[A, B](nameA, nameB, resolver)
+	if err != nil {
+		return a, b, c, err
+	}
+	c, err = Resolve[C](nameC, resolver)
+	return a, b, c, err
+}
+
+// MustResolveAll resolves all given names, discarding their values, and
+// panics with an error listing every failed resolution. It is meant to
+// assert at startup, that all critical dependencies can be constructed.
+func MustResolveAll(resolver ResolverFunc, names ...string) {
+	var errs []error
+	var failed []string
+	for _, name := range names {
+		if _, err := resolver(name); err != nil {
+			errs = append(errs, err)
+			failed = append(failed, name)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		for i, name := range failed {
+			ReportPanic(name, errs[i])
+		}
+		panic(err)
+	}
+}
+
+// FactoryOf is a helper function for code, which constructs many instances
+// of a dependency over time, like one per job. FactoryOf returns a typed
+// factory, which resolves the named dependency through the given
+// ResolverFunc on every call, like Resolve does.
+func FactoryOf[T any](name string, resolver ResolverFunc) func() (T, error) {
+	return func() (T, error) {
+		return Resolve[T](name, resolver)
+	}
+}
@@ -1,7 +1,10 @@
 package godi
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestResolve(t *testing.T) {
@@ -50,3 +53,177 @@ func TestMustResolve(t *testing.T) {
 	}()
 	MustResolve[int]("test", container.Resolver())
 }
+
+func TestResolveInto(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return 1
+	})
+	container.MustBind("nil", func(resolver ResolverFunc) any {
+		return nil
+	})
+	resolver := container.Resolver()
+
+	var i int
+	if err := ResolveInto("foo", &i, resolver); err != nil || i != 1 {
+		t.Fatalf("Unable to resolve into int: %v", err)
+	}
+	var a any
+	if err := ResolveInto("foo", &a, resolver); err != nil || a != 1 {
+		t.Fatalf("Unable to resolve into interface: %v", err)
+	}
+	var s string
+	if err := ResolveInto("foo", &s, resolver); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Resolved int into string")
+	}
+	if err := ResolveInto("foo", i, resolver); err == nil {
+		t.Fatalf("Resolved into non-pointer")
+	}
+	if err := ResolveInto("foo", (*int)(nil), resolver); err == nil {
+		t.Fatalf("Resolved into nil pointer")
+	}
+	if err := ResolveInto("bar", &i, resolver); err == nil {
+		t.Fatalf("Resolved unknown dependency")
+	}
+	p := &i
+	if err := ResolveInto("nil", &p, resolver); err != nil || p != nil {
+		t.Fatalf("Unable to resolve nil into pointer: %v", err)
+	}
+	if err := ResolveInto("nil", &i, resolver); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Resolved nil into int")
+	}
+}
+
+func TestResolve2(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return 1
+	})
+	container.MustBind("bar", func(resolver ResolverFunc) any {
+		return "bar"
+	})
+	resolver := container.Resolver()
+	foo, bar, err := Resolve2[int, string]("foo", "bar", resolver)
+	if err != nil || foo != 1 || bar != "bar" {
+		t.Fatalf("Unable to resolve two dependencies: %v", err)
+	}
+	if _, _, err = Resolve2[int, int]("foo", "bar", resolver); err == nil {
+		t.Fatalf("Resolved string as int")
+	}
+	if _, _, err = Resolve2[int, string]("baz", "bar", resolver); err == nil {
+		t.Fatalf("Resolved unknown dependency")
+	}
+}
+
+func TestResolve3(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return 1
+	})
+	container.MustBind("bar", func(resolver ResolverFunc) any {
+		return "bar"
+	})
+	resolver := container.Resolver()
+	foo, bar, again, err := Resolve3[int, string, int]("foo", "bar", "foo", resolver)
+	if err != nil || foo != 1 || bar != "bar" || again != 1 {
+		t.Fatalf("Unable to resolve three dependencies: %v", err)
+	}
+	if _, _, _, err = Resolve3[int, string, int]("foo", "bar", "baz", resolver); err == nil {
+		t.Fatalf("Resolved unknown dependency")
+	}
+}
+
+func TestMustResolveAll(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("foo", func(resolver ResolverFunc) any {
+		return 1
+	})
+	MustResolveAll(container.Resolver(), "foo")
+
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil || !strings.Contains(err.Error(), "bar") || !strings.Contains(err.Error(), "baz") {
+			t.Fatalf("Expected panic listing all failures, got %v", err)
+		}
+	}()
+	MustResolveAll(container.Resolver(), "foo", "bar", "baz")
+}
+
+func TestFactoryOf(t *testing.T) {
+	container := NewContainer()
+	var count int
+	container.MustBind("job", func(resolver ResolverFunc) any {
+		count++
+		return count
+	})
+	factory := FactoryOf[int]("job", container.Resolver())
+	for i := 1; i <= 3; i++ {
+		if v, err := factory(); err != nil || v != i {
+			t.Fatalf("Expected instance %d, got %d: %v", i, v, err)
+		}
+	}
+	if _, err := FactoryOf[string]("job", container.Resolver())(); err == nil {
+		t.Fatalf("Factory converted dependency to wrong type")
+	}
+}
+
+func TestAsTyped(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("json", func(resolver ResolverFunc) any {
+		return "application/json"
+	})
+	container.MustBind("port", func(resolver ResolverFunc) any {
+		return 8080
+	})
+	codecs := AsTyped[string](container.Resolver())
+	if v, err := codecs("json"); err != nil || v != "application/json" {
+		t.Fatalf("Unable to resolve through typed resolver: %v", err)
+	}
+	if _, err := codecs("port"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Typed resolver converted dependency to wrong type: %v", err)
+	}
+}
+
+type headerList []string
+
+func TestResolveAssignable(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("timeout", func(resolver ResolverFunc) any {
+		return 5 * time.Second
+	})
+	container.MustBind("headers", func(resolver ResolverFunc) any {
+		return headerList{"Accept"}
+	})
+	container.MustBind("port", func(resolver ResolverFunc) any {
+		return 8080
+	})
+	container.MustBind("nil", func(resolver ResolverFunc) any {
+		return nil
+	})
+	resolver := container.Resolver()
+
+	if v, err := ResolveAssignable[int64]("timeout", resolver); err != nil || v != int64(5*time.Second) {
+		t.Fatalf("Unable to resolve convertible dependency: %v", err)
+	}
+	if v, err := ResolveAssignable[[]string]("headers", resolver); err != nil || len(v) != 1 {
+		t.Fatalf("Unable to resolve assignable dependency: %v", err)
+	}
+	if v, err := ResolveAssignable[time.Duration]("timeout", resolver); err != nil || v != 5*time.Second {
+		t.Fatalf("Unable to resolve dependency of exact type: %v", err)
+	}
+	if _, err := ResolveAssignable[string]("port", resolver); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Converted integer to string: %v", err)
+	}
+	if _, err := ResolveAssignable[[]int]("headers", resolver); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Converted dependency to incompatible type: %v", err)
+	}
+	if v, err := ResolveAssignable[*strings.Builder]("nil", resolver); err != nil || v != nil {
+		t.Fatalf("Unable to resolve nil dependency as pointer: %v", err)
+	}
+	if v, err := ResolveAssignable[error]("nil", resolver); err != nil || v != nil {
+		t.Fatalf("Unable to resolve nil dependency as interface: %v", err)
+	}
+	if _, err := ResolveAssignable[int]("missing", resolver); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Resolved missing dependency: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// Scope is an isolated resolution context derived from a Container, like
+// a request, a message or a database transaction. Values provided to the
+// Scope shadow the dependencies of the Container for all resolutions
+// performed through the Scope's ResolverFunc, including the nested
+// resolutions of instanced dependencies. Singleton dependencies are always
+// constructed outside the Scope, so they never capture scoped values.
+//
+// Dependencies bound with the Scoped option are constructed once per Scope
+// and cached within it, isolated from all other Scopes.
+//
+// Once the unit of work is done, the Scope is closed, which discards all
+// provided values and closes the scoped instances implementing io.Closer,
+// dependents before their dependencies. Scopes created with the
+// TrackTransients option additionally close the instanced values
+// constructed within them, in reverse order of their construction and
+// before the scoped instances. Scopes created with the CloseOnDone option
+// are closed automatically, once their context is done. Resolutions
+// through a closed Scope fail.
+type Scope interface {
+	Provide(name string, value any) error
+	Resolver() ResolverFunc
+	ResolverContext(ctx context.Context) ResolverFunc
+	Close() error
+}
+
+// ScopeOption configures a Scope created by NewScope.
+type ScopeOption func(s *defaultScope)
+
+// TrackTransients records all instanced values implementing io.Closer,
+// which are constructed within the Scope, and closes them together with
+// the Scope, so per-request transients like temporary files can't leak
+// past the unit of work.
+func TrackTransients() ScopeOption {
+	return func(s *defaultScope) {
+		s.tracking = true
+	}
+}
+
+// CloseOnDone attaches the Scope to the given context, closing it as soon
+// as the context is done. The Scope is disposed even if the deferred Close
+// of a middleware is bypassed, e.g. by a request, which was cancelled.
+// Errors closing the Scope are logged by the logger of the Container.
+func CloseOnDone(ctx context.Context) ScopeOption {
+	return func(s *defaultScope) {
+		s.stop = context.AfterFunc(ctx, func() {
+			if err := s.Close(); err != nil {
+				s.container.log().Error("scope close failed", slog.Any("error", err))
+			}
+		})
+	}
+}
+
+type scopeKey struct{}
+
+type defaultScope struct {
+	container *defaultContainer
+	mu        sync.RWMutex
+	closed    bool
+	values    map[string]any
+	instances map[*binding]*instance
+	names     map[*binding]string
+	tracking  bool
+	transient []io.Closer
+	stop      func() bool
+}
+
+func (d *defaultContainer) NewScope(options ...ScopeOption) Scope {
+	s := &defaultScope{
+		container: d,
+		values:    make(map[string]any),
+		instances: make(map[*binding]*instance),
+		names:     make(map[*binding]string),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *defaultScope) Provide(name string, value any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("scope closed. no more values can be provided")
+	}
+	if _, ok := s.values[name]; ok {
+		return errors.New(fmt.Sprintf("value with name %s already provided", name))
+	}
+	s.values[name] = value
+	return nil
+}
+
+func (s *defaultScope) Resolver() ResolverFunc {
+	return s.ResolverContext(context.Background())
+}
+
+func (s *defaultScope) ResolverContext(ctx context.Context) ResolverFunc {
+	return s.container.resolver(context.WithValue(ctx, scopeKey{}, s))
+}
+
+func (s *defaultScope) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	built := make(map[string]*instance, len(s.instances))
+	names := make([]string, 0, len(s.instances))
+	for b, inst := range s.instances {
+		if inst.built.Load() {
+			built[s.names[b]] = inst
+			names = append(names, s.names[b])
+		}
+	}
+	transient := s.transient
+	if s.stop != nil {
+		s.stop()
+	}
+	s.closed = true
+	s.values = nil
+	s.instances = nil
+	s.names = nil
+	s.transient = nil
+	s.mu.Unlock()
+	sort.Strings(names)
+
+	var errs []error
+	for i := len(transient) - 1; i >= 0; i-- {
+		err := transient[i].Close()
+		s.container.releaseInstance(transient[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to close transient service: %w", err))
+		}
+	}
+	for _, name := range s.container.closeOrder(names) {
+		closer, ok := built[name].value.(io.Closer)
+		if !ok {
+			continue
+		}
+		err := closer.Close()
+		s.container.releaseInstance(closer)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to close scoped %s service: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lookup returns the value provided for the given name and reports
+// an error, if the Scope was closed already.
+func (s *defaultScope) lookup(name string) (any, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, false, errors.New(fmt.Sprintf("unable to resolve %s service. scope closed", name))
+	}
+	value, ok := s.values[name]
+	return value, ok, nil
+}
+
+// instanceOf returns the instance holding the value of the given scoped
+// binding within the Scope.
+func (s *defaultScope) instanceOf(name string, b *binding) (*instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New(fmt.Sprintf("unable to resolve %s service. scope closed", name))
+	}
+	inst, ok := s.instances[b]
+	if !ok {
+		inst = &instance{}
+		s.instances[b] = inst
+		s.names[b] = name
+	}
+	return inst, nil
+}
+
+// track records the given instanced value to be closed with the Scope,
+// if the Scope tracks transients. Values constructed after the Scope was
+// closed are closed immediately.
+func (s *defaultScope) track(value any) {
+	closer, ok := value.(io.Closer)
+	if !ok || !s.tracking {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		_ = closer.Close()
+		s.container.releaseInstance(closer)
+		return
+	}
+	s.transient = append(s.transient, closer)
+}
+
+// scopeFrom returns the Scope a resolution is performed in, if any.
+func scopeFrom(ctx context.Context) *defaultScope {
+	s, _ := ctx.Value(scopeKey{}).(*defaultScope)
+	return s
+}
+
+// withoutScope detaches a context from its Scope, so singletons
+// constructed within it can't capture scoped values.
+func withoutScope(ctx context.Context) context.Context {
+	if scopeFrom(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, scopeKey{}, (*defaultScope)(nil))
+}
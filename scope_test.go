@@ -0,0 +1,151 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDefaultContainer_NewScope(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("user", func(resolver ResolverFunc) any {
+		return "anonymous"
+	})
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello " + MustResolve[string]("user", resolver)
+	})
+	container.MustBindSingleton("cached-greeting", func(resolver ResolverFunc) any {
+		return "hello " + MustResolve[string]("user", resolver)
+	})
+	container.Lock()
+
+	scope := container.NewScope()
+	if err := scope.Provide("user", "gopher"); err != nil {
+		t.Fatalf("Unable to provide scoped value: %s", err)
+	}
+	if err := scope.Provide("user", "other"); err == nil {
+		t.Fatalf("Could override already provided value %s", "user")
+	}
+
+	resolver := scope.Resolver()
+	if v := MustResolve[string]("greeting", resolver); v != "hello gopher" {
+		t.Fatalf("Nested resolution did not use scoped value. Got %s", v)
+	}
+	if v := MustResolve[string]("cached-greeting", resolver); v != "hello anonymous" {
+		t.Fatalf("Singleton captured scoped value. Got %s", v)
+	}
+	if v := MustResolve[string]("greeting", container.Resolver()); v != "hello anonymous" {
+		t.Fatalf("Scoped value leaked into container. Got %s", v)
+	}
+
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Unable to close scope: %s", err)
+	}
+	if _, err := resolver("greeting"); err == nil {
+		t.Fatalf("Resolved dependency through closed scope")
+	}
+	if err := scope.Provide("other", 1); err == nil {
+		t.Fatalf("Provided value to closed scope")
+	}
+}
+
+func TestDefaultScope_Close(t *testing.T) {
+	var closed []string
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("tx", func(resolver ResolverFunc) any {
+		return &orderedCloser{name: "tx", closed: &closed, err: errors.New("rollback failed")}
+	}, Scoped())
+	container.MustBind("repository", func(resolver ResolverFunc) any {
+		MustResolve[*orderedCloser]("tx", resolver)
+		return &orderedCloser{name: "repository", closed: &closed}
+	}, Scoped())
+	container.MustBind("unused", func(resolver ResolverFunc) any {
+		return &orderedCloser{name: "unused", closed: &closed}
+	}, Scoped())
+
+	scope := container.NewScope()
+	MustResolve[*orderedCloser]("repository", scope.Resolver())
+	if err := scope.Close(); err == nil {
+		t.Fatalf("Close error of scoped instance not reported")
+	}
+	if !reflect.DeepEqual(closed, []string{"repository", "tx"}) {
+		t.Fatalf("Unexpected close order %v", closed)
+	}
+	if err := scope.Close(); err != nil || len(closed) != 2 {
+		t.Fatalf("Closed scope more than once")
+	}
+}
+
+func TestTrackTransients(t *testing.T) {
+	var closed []string
+	var count int
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("tx", func(resolver ResolverFunc) any {
+		return &orderedCloser{name: "tx", closed: &closed}
+	}, Scoped())
+	container.MustBind("file", func(resolver ResolverFunc) any {
+		MustResolve[*orderedCloser]("tx", resolver)
+		count++
+		return &orderedCloser{name: fmt.Sprintf("file-%d", count), closed: &closed}
+	})
+	container.MustBindSingleton("cache", func(resolver ResolverFunc) any {
+		return &orderedCloser{name: "cache", closed: &closed}
+	})
+
+	untracked := container.NewScope()
+	MustResolve[*orderedCloser]("file", untracked.Resolver())
+	_ = untracked.Close()
+	if !reflect.DeepEqual(closed, []string{"tx"}) {
+		t.Fatalf("Transient closed by untracked scope: %v", closed)
+	}
+
+	closed = nil
+	scope := container.NewScope(TrackTransients())
+	MustResolve[*orderedCloser]("file", scope.Resolver())
+	MustResolve[*orderedCloser]("file", scope.Resolver())
+	MustResolve[*orderedCloser]("cache", scope.Resolver())
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Unable to close scope: %s", err)
+	}
+	if !reflect.DeepEqual(closed, []string{"file-3", "file-2", "tx"}) {
+		t.Fatalf("Unexpected close order %v", closed)
+	}
+}
+
+type signalCloser chan struct{}
+
+func (c signalCloser) Close() error {
+	close(c)
+	return nil
+}
+
+func TestCloseOnDone(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("tx", func(resolver ResolverFunc) any {
+		return make(signalCloser)
+	}, Scoped())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scope := container.NewScope(CloseOnDone(ctx))
+	tx := MustResolve[signalCloser]("tx", scope.Resolver())
+	cancel()
+	select {
+	case <-tx:
+	case <-time.After(time.Second):
+		t.Fatalf("Scope not closed after context was done")
+	}
+	if _, err := scope.Resolver()("tx"); err == nil {
+		t.Fatalf("Resolved dependency through scope closed by its context")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	scope = container.NewScope(CloseOnDone(ctx))
+	MustResolve[signalCloser]("tx", scope.Resolver())
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Unable to close scope: %s", err)
+	}
+	cancel()
+}
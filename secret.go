@@ -0,0 +1,58 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SecretsProvider fetches secrets by their key from a secret store,
+// like Vault or AWS SSM.
+type SecretsProvider interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// WithSecretsProvider sets the SecretsProvider used to resolve dependencies
+// bound through ExtendedBinder.BindSecret. Fetched secrets are cached for the
+// given ttl. A ttl of zero fetches the secret on every resolution.
+func WithSecretsProvider(provider SecretsProvider, ttl time.Duration) ContainerOption {
+	return func(d *defaultContainer) {
+		d.secrets = provider
+		d.secretsTTL = ttl
+	}
+}
+
+type secret struct {
+	mu      sync.Mutex
+	value   string
+	expires time.Time
+}
+
+// fetch returns the cached secret or fetches it from the provider,
+// if the cached value expired.
+func (s *secret) fetch(ctx context.Context, provider SecretsProvider, key string, ttl time.Duration) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Now().Before(s.expires) {
+		return s.value, nil
+	}
+	value, err := provider.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	s.value = value
+	s.expires = time.Now().Add(ttl)
+	return value, nil
+}
+
+func (d *defaultContainer) BindSecret(name, key string) error {
+	if d.secrets == nil {
+		return errors.New("no secrets provider configured. secrets can not be bound")
+	}
+	provider, ttl := d.secrets, d.secretsTTL
+	s := &secret{}
+	return d.bind(name, newBinding(func(ctx context.Context, _ ResolverFunc) (any, error) {
+		return s.fetch(ctx, provider, key, ttl)
+	}, false))
+}
@@ -0,0 +1,50 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type staticSecrets struct {
+	values  map[string]string
+	fetches int
+}
+
+func (s *staticSecrets) Get(ctx context.Context, key string) (string, error) {
+	s.fetches++
+	value, ok := s.values[key]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return value, nil
+}
+
+func TestDefaultContainer_BindSecret(t *testing.T) {
+	if err := NewContainer().(*defaultContainer).BindSecret("db-password", "db/password"); err == nil {
+		t.Fatalf("Bound secret without secrets provider")
+	}
+
+	provider := &staticSecrets{values: map[string]string{"db/password": "hunter2"}}
+	container := NewContainer(WithSecretsProvider(provider, time.Hour)).(*defaultContainer)
+	if err := container.BindSecret("db-password", "db/password"); err != nil {
+		t.Fatalf("Unable to bind secret: %s", err)
+	}
+	if err := container.BindSecret("api-key", "api/key"); err != nil {
+		t.Fatalf("Unable to bind secret: %s", err)
+	}
+
+	resolver := container.ResolverContext(context.Background())
+	for i := 0; i < 3; i++ {
+		if v := MustResolve[string]("db-password", resolver); v != "hunter2" {
+			t.Fatalf("Unexpected secret value %s", v)
+		}
+	}
+	if provider.fetches != 1 {
+		t.Fatalf("Expected cached secret to be fetched once, got %d fetches", provider.fetches)
+	}
+	if _, err := resolver("api-key"); err == nil {
+		t.Fatalf("Resolved secret missing in provider")
+	}
+}
@@ -0,0 +1,81 @@
+package godi
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// BindingStats contains resolution statistics of a single dependency,
+// as reported by Introspector.Stats.
+type BindingStats struct {
+	// Resolutions is the number of successful resolutions.
+	Resolutions uint64
+	// Failures is the number of failed resolutions.
+	Failures uint64
+	// LastResolved is the time of the last successful resolution,
+	// or the zero time, if the dependency was never resolved.
+	LastResolved time.Time
+	// ConstructionTime is the duration it took to construct the instance
+	// of a singleton dependency.
+	ConstructionTime time.Duration
+	// Alive is the number of instances currently alive, if the Container
+	// tracks its instances, see WithInstanceTracking.
+	Alive int64
+}
+
+type bindingStats struct {
+	resolutions      atomic.Uint64
+	failures         atomic.Uint64
+	lastResolved     atomic.Int64
+	constructionTime atomic.Int64
+	alive            atomic.Int64
+}
+
+func (s *bindingStats) resolved() {
+	s.resolutions.Add(1)
+	s.lastResolved.Store(time.Now().UnixNano())
+}
+
+func (s *bindingStats) failed() {
+	s.failures.Add(1)
+}
+
+func (s *bindingStats) constructed(duration time.Duration) {
+	s.constructionTime.Store(int64(duration))
+}
+
+func (s *bindingStats) snapshot() BindingStats {
+	stats := BindingStats{
+		Resolutions:      s.resolutions.Load(),
+		Failures:         s.failures.Load(),
+		ConstructionTime: time.Duration(s.constructionTime.Load()),
+		Alive:            s.alive.Load(),
+	}
+	if last := s.lastResolved.Load(); last != 0 {
+		stats.LastResolved = time.Unix(0, last)
+	}
+	return stats
+}
+
+func (d *defaultContainer) Stats() map[string]BindingStats {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	stats := make(map[string]BindingStats)
+	d.table.eachService(func(name string, _ *binding) {
+		stats[name] = BindingStats{}
+		if s, ok := d.stats.Load(name); ok {
+			stats[name] = s.(*bindingStats).snapshot()
+		}
+	})
+	return stats
+}
+
+// statsOf returns the statistics of the named dependency. Statistics are
+// kept by name, so they survive changes of the binding.
+func (d *defaultContainer) statsOf(name string) *bindingStats {
+	if s, ok := d.stats.Load(name); ok {
+		return s.(*bindingStats)
+	}
+	s, _ := d.stats.LoadOrStore(name, &bindingStats{})
+	return s.(*bindingStats)
+}
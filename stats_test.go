@@ -0,0 +1,50 @@
+package godi
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDefaultContainer_Stats(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBindSingleton("slow", func(resolver ResolverFunc) any {
+		time.Sleep(time.Millisecond)
+		return true
+	})
+	container.MustBind("invalid", func(resolver ResolverFunc) any {
+		return false
+	})
+	container.MustBind("unused", func(resolver ResolverFunc) any {
+		return true
+	})
+	container.OnResolved(func(name string, value any) error {
+		if name == "invalid" {
+			return errors.New("invalid")
+		}
+		return nil
+	})
+
+	resolver := container.Resolver()
+	MustResolve[bool]("slow", resolver)
+	MustResolve[bool]("slow", resolver)
+	_, _ = resolver("invalid")
+
+	stats := container.Stats()
+	if len(stats) != 4 {
+		t.Fatalf("Expected stats for %d dependencies, got %d", 3, len(stats))
+	}
+	slow := stats["slow"]
+	if slow.Resolutions != 2 || slow.Failures != 0 || slow.LastResolved.IsZero() {
+		t.Fatalf("Unexpected stats for dependency %s: %+v", "slow", slow)
+	}
+	if slow.ConstructionTime < time.Millisecond {
+		t.Fatalf("Unexpected construction time for dependency %s: %s", "slow", slow.ConstructionTime)
+	}
+	if invalid := stats["invalid"]; invalid.Resolutions != 0 || invalid.Failures != 1 {
+		t.Fatalf("Unexpected stats for dependency %s: %+v", "invalid", invalid)
+	}
+	if unused := stats["unused"]; unused.Resolutions != 0 || !unused.LastResolved.IsZero() {
+		t.Fatalf("Unexpected stats for dependency %s: %+v", "unused", unused)
+	}
+}
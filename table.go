@@ -0,0 +1,90 @@
+package godi
+
+// maxTableDepth limits the number of layered tables a lookup has to walk.
+// Deeper tables are flattened, when the Container is cloned.
+const maxTableDepth = 16
+
+// table holds the bindings and aliases of a Container. Tables are shared
+// copy-on-write between a Container and its clones. Once shared, a table
+// is frozen and changes are recorded by a new table layered on top of it,
+// so cloning does not copy any bindings.
+type table struct {
+	parent   *table
+	depth    int
+	services map[string]*binding
+	aliases  map[string]string
+}
+
+func newTable(parent *table) *table {
+	t := &table{
+		parent:   parent,
+		services: make(map[string]*binding),
+		aliases:  make(map[string]string),
+	}
+	if parent != nil {
+		t.depth = parent.depth + 1
+	}
+	return t
+}
+
+func (t *table) empty() bool {
+	return len(t.services) == 0 && len(t.aliases) == 0
+}
+
+func (t *table) service(name string) (*binding, bool) {
+	for ; t != nil; t = t.parent {
+		if b, ok := t.services[name]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+func (t *table) alias(name string) (string, bool) {
+	for ; t != nil; t = t.parent {
+		if target, ok := t.aliases[name]; ok {
+			return target, true
+		}
+	}
+	return "", false
+}
+
+// eachService calls fn for every bound dependency, preferring bindings
+// of upper tables over the bindings they replace.
+func (t *table) eachService(fn func(name string, b *binding)) {
+	seen := make(map[string]bool)
+	for ; t != nil; t = t.parent {
+		for name, b := range t.services {
+			if !seen[name] {
+				seen[name] = true
+				fn(name, b)
+			}
+		}
+	}
+}
+
+// eachAlias calls fn for every alias, preferring the targets of upper
+// tables over the targets they replace.
+func (t *table) eachAlias(fn func(name, target string)) {
+	seen := make(map[string]bool)
+	for ; t != nil; t = t.parent {
+		for name, target := range t.aliases {
+			if !seen[name] {
+				seen[name] = true
+				fn(name, target)
+			}
+		}
+	}
+}
+
+// flatten merges all layers of t into a single table.
+func (t *table) flatten() *table {
+	flat := newTable(nil)
+	t.eachService(func(name string, b *binding) {
+		flat.services[name] = b
+	})
+	t.eachAlias(func(name, target string) {
+		flat.aliases[name] = target
+	})
+	return flat
+}
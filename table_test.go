@@ -0,0 +1,69 @@
+package godi
+
+import (
+	"testing"
+)
+
+func TestTable_Layers(t *testing.T) {
+	base := newTable(nil)
+	base.services["a"] = newBinding(nil, false)
+	base.services["b"] = newBinding(nil, false)
+	base.aliases["c"] = "a"
+
+	top := newTable(base)
+	replaced := newBinding(nil, true)
+	top.services["b"] = replaced
+	top.aliases["c"] = "b"
+
+	if b, ok := top.service("b"); !ok || b != replaced {
+		t.Fatalf("Upper table does not replace binding")
+	}
+	if _, ok := top.service("a"); !ok {
+		t.Fatalf("Binding of lower table not found")
+	}
+	if target, _ := top.alias("c"); target != "b" {
+		t.Fatalf("Upper table does not replace alias, got %s", target)
+	}
+	var count int
+	top.eachService(func(name string, b *binding) {
+		count++
+		if name == "b" && b != replaced {
+			t.Fatalf("Replaced binding visited")
+		}
+	})
+	if count != 2 {
+		t.Fatalf("Expected 2 bindings, visited %d", count)
+	}
+
+	flat := top.flatten()
+	if flat.parent != nil || flat.depth != 0 || len(flat.services) != 2 || flat.aliases["c"] != "b" {
+		t.Fatalf("Table not flattened correctly")
+	}
+}
+
+func TestDefaultContainer_Clone_Layers(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	for i := 0; i < 3*maxTableDepth; i++ {
+		clone := container.Clone().(*defaultContainer)
+		if err := clone.Swap("greeting", func(resolver ResolverFunc) any {
+			return "hi"
+		}); err != nil {
+			t.Fatalf("Unable to swap cloned dependency: %s", err)
+		}
+		if err := container.Tag("greeting", "text"); err != nil {
+			t.Fatalf("Unable to tag dependency: %s", err)
+		}
+		if tagged := len(clone.Tagged("text")) == 1; tagged != (i > 0) {
+			t.Fatalf("Tag of container changed clone")
+		}
+	}
+	if depth := container.table.depth; depth > maxTableDepth {
+		t.Fatalf("Table depth %d exceeds limit", depth)
+	}
+	if MustResolve[string]("greeting", container.Resolver()) != "hello" {
+		t.Fatalf("Swap of clone changed original container")
+	}
+}
@@ -0,0 +1,54 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+func (d *defaultContainer) Tag(name string, tags ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to tag %s service: %w", name, ErrFrozen)
+	}
+	if d.locked.Load() {
+		return errors.New("service container locked. no more services can be tagged")
+	}
+	b, ok := d.table.service(name)
+	if !ok {
+		return &notFoundError{name: name}
+	}
+	next := append([]string(nil), b.tags...)
+	for _, tag := range tags {
+		if !hasTag(next, tag) {
+			next = append(next, tag)
+		}
+	}
+	replaced := b.copy()
+	replaced.tags = next
+	d.replace(name, b, replaced)
+	return nil
+}
+
+func (d *defaultContainer) Tagged(tag string) []string {
+	d.mu.RLock()
+	var names []string
+	d.table.eachService(func(name string, b *binding) {
+		if hasTag(b.tags, tag) {
+			names = append(names, name)
+		}
+	})
+	d.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package godi
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDefaultContainer_Tag(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	handler := func(resolver ResolverFunc) any {
+		return true
+	}
+	for _, name := range []string{"foo", "bar", "baz"} {
+		container.MustBind(name, handler)
+	}
+	if err := container.Tag("foo", "job", "nightly"); err != nil {
+		t.Fatalf("Unable to tag dependency %s: %s", "foo", err)
+	}
+	if err := container.Tag("bar", "job", "job"); err != nil {
+		t.Fatalf("Unable to tag dependency %s: %s", "bar", err)
+	}
+	if err := container.Tag("qux", "job"); err == nil {
+		t.Fatalf("Tagged non existing dependency %s", "qux")
+	}
+
+	if names := fmt.Sprint(container.Tagged("job")); names != "[bar foo]" {
+		t.Fatalf("Unexpected tagged dependencies %s", names)
+	}
+	if names := container.Tagged("missing"); len(names) != 0 {
+		t.Fatalf("Unexpected tagged dependencies %v", names)
+	}
+	info, _ := container.Info("bar")
+	if fmt.Sprint(info.Tags) != "[job]" {
+		t.Fatalf("Unexpected tags of dependency %s: %v", "bar", info.Tags)
+	}
+
+	err := container.Swap("foo", handler)
+	if err != nil {
+		t.Fatalf("Unable to swap dependency %s: %s", "foo", err)
+	}
+	if names := fmt.Sprint(container.Tagged("nightly")); names != "[foo]" {
+		t.Fatalf("Tags lost on swap. Got %s", names)
+	}
+
+	container.Lock()
+	if err = container.Tag("baz", "job"); err == nil {
+		t.Fatalf("Tagged dependency of locked container")
+	}
+}
@@ -0,0 +1,66 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WithInitTimeout sets the default time the construction of a dependency
+// may take, see InitTimeout. By default, constructions are not limited.
+func WithInitTimeout(timeout time.Duration) ContainerOption {
+	return func(d *defaultContainer) {
+		d.initTimeout = timeout
+	}
+}
+
+type invocation struct {
+	value    any
+	err      error
+	panicked any
+}
+
+// invokeWithin invokes the factory of b within its init timeout. The
+// context of the construction is cancelled, once the timeout is exceeded.
+// Values constructed after the timeout are discarded and closed, if they
+// implement io.Closer.
+func (d *defaultContainer) invokeWithin(ctx context.Context, name string, b *binding) (any, error) {
+	timeout := b.initTimeout
+	if timeout == 0 {
+		timeout = d.initTimeout
+	}
+	if timeout <= 0 {
+		return d.invoke(ctx, name, b)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	done := make(chan invocation, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- invocation{panicked: r}
+			}
+		}()
+		value, err := d.invoke(ctx, name, b)
+		done <- invocation{value: value, err: err}
+	}()
+	select {
+	case result := <-done:
+		if result.panicked != nil {
+			panic(result.panicked)
+		}
+		return result.value, result.err
+	case <-ctx.Done():
+		go func() {
+			if closer, ok := (<-done).value.(io.Closer); ok {
+				_ = closer.Close()
+			}
+		}()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("construction of %s service timed out after %s: %w", name, timeout, ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+}
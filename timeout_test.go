@@ -0,0 +1,43 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInitTimeout(t *testing.T) {
+	container := NewContainer(WithInitTimeout(time.Second))
+	release := make(chan struct{})
+	defer close(release)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		<-release
+		return &closeRecorder{}
+	}, InitTimeout(10*time.Millisecond))
+	container.MustBind("fast", func(resolver ResolverFunc) any {
+		return "fast"
+	})
+
+	_, err := container.Resolver()("db")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "db service timed out") {
+		t.Fatalf("Expected timeout error naming the service, got %v", err)
+	}
+	if MustResolve[string]("fast", container.Resolver()) != "fast" {
+		t.Fatalf("Unable to resolve dependency within the default timeout")
+	}
+}
+
+func TestInitTimeout_Panic(t *testing.T) {
+	container := NewContainer(WithInitTimeout(time.Second))
+	container.MustBind("panicking", func(resolver ResolverFunc) any {
+		panic("broken")
+	})
+	defer func() {
+		if r := recover(); r != "broken" {
+			t.Fatalf("Expected panic of the binder, got %v", r)
+		}
+	}()
+	_, _ = container.Resolver()("panicking")
+}
@@ -0,0 +1,78 @@
+package godi
+
+import (
+	"errors"
+	"reflect"
+)
+
+// NameOf returns the canonical name of the type T, under which
+// BindConstructorAuto binds constructors returning T. Named types are
+// qualified by their package path, like "github.com/acme/app/user.Service".
+// Pointers are named by the type they point to.
+func NameOf[T any]() string {
+	return TypeName(typeOf[T]())
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// TypeName returns the canonical name of the given type, like NameOf does
+// for types known at compile time.
+func TypeName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer && t.Name() == "" {
+		t = t.Elem()
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// BindConstructorAuto binds the given constructor to the Container under
+// the canonical name of its return type, as reported by NameOf. This
+// removes hand-maintained names for dependencies, which are bound only
+// once per type. The derived name is returned.
+func BindConstructorAuto[T any](c Container, constructor func(resolver ResolverFunc) T, options ...BindOption) (string, error) {
+	name := qualify(NameOf[T](), options)
+	return name, c.Bind(NameOf[T](), func(resolver ResolverFunc) any {
+		return constructor(resolver)
+	}, append(options, DeclareType(typeOf[T]()))...)
+}
+
+// BindSingletonConstructorAuto binds the given constructor as a singleton,
+// like BindConstructorAuto does.
+func BindSingletonConstructorAuto[T any](c Container, constructor func(resolver ResolverFunc) T, options ...BindOption) (string, error) {
+	name := qualify(NameOf[T](), options)
+	return name, c.BindSingleton(NameOf[T](), func(resolver ResolverFunc) any {
+		return constructor(resolver)
+	}, append(options, DeclareType(typeOf[T]()))...)
+}
+
+// BindTypeOf binds the given binder to the Container under the canonical
+// name of the type of value, for frameworks wiring types only known at
+// runtime. Interface types are passed as nil pointers, like (*io.Reader)(nil).
+func BindTypeOf(c Container, value any, binder BinderFunc, options ...BindOption) error {
+	if value == nil {
+		return errors.New("unable to bind type of nil")
+	}
+	t := reflect.TypeOf(value)
+	if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Interface {
+		t = t.Elem()
+	}
+	return c.Bind(TypeName(t), binder, append(options, DeclareType(t))...)
+}
+
+// ResolveByType resolves the dependency bound under the canonical name of
+// the given type and verifies, that it is assignable to the type.
+func ResolveByType(c Container, t reflect.Type) (any, error) {
+	name := TypeName(t)
+	value, err := c.Resolver()(name)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil || !reflect.TypeOf(value).AssignableTo(t) {
+		return nil, &typeMismatchError{name: name, requested: t, actual: reflect.TypeOf(value)}
+	}
+	return value, nil
+}
@@ -0,0 +1,83 @@
+package godi
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type typeNameService struct {
+	name string
+}
+
+func TestNameOf(t *testing.T) {
+	for expected, name := range map[string]string{
+		"github.com/jschaefer-io/godi.typeNameService": NameOf[typeNameService](),
+		"io.Reader": NameOf[io.Reader](),
+		"int":       NameOf[int](),
+		"[]string":  NameOf[[]string](),
+	} {
+		if name != expected {
+			t.Fatalf("Unexpected name. Expected %s got %s", expected, name)
+		}
+	}
+	if NameOf[*typeNameService]() != NameOf[typeNameService]() {
+		t.Fatalf("Pointer not named by the type it points to")
+	}
+}
+
+func TestBindConstructorAuto(t *testing.T) {
+	container := NewContainer()
+	name, err := BindConstructorAuto(container, func(resolver ResolverFunc) *typeNameService {
+		return &typeNameService{name: "auto"}
+	})
+	if err != nil {
+		t.Fatalf("Unable to bind constructor: %s", err)
+	}
+	if name != "github.com/jschaefer-io/godi.typeNameService" {
+		t.Fatalf("Unexpected derived name %s", name)
+	}
+	service := MustResolve[*typeNameService](NameOf[*typeNameService](), container.Resolver())
+	if service.name != "auto" {
+		t.Fatalf("Constructor not bound")
+	}
+	if _, err = BindSingletonConstructorAuto(container, func(resolver ResolverFunc) *typeNameService {
+		return &typeNameService{}
+	}); err == nil {
+		t.Fatalf("Bound constructor of the same type twice")
+	}
+}
+
+func TestBindTypeOf(t *testing.T) {
+	container := NewContainer()
+	err := BindTypeOf(container, (*io.Reader)(nil), func(resolver ResolverFunc) any {
+		return strings.NewReader("text")
+	})
+	if err != nil {
+		t.Fatalf("Unable to bind type: %s", err)
+	}
+	if err = BindTypeOf(container, nil, nil); err == nil {
+		t.Fatalf("Bound type of nil")
+	}
+	if err = BindTypeOf(container, 0, func(resolver ResolverFunc) any {
+		return "text"
+	}); err != nil {
+		t.Fatalf("Unable to bind type: %s", err)
+	}
+
+	reader, err := ResolveByType(container, reflect.TypeOf((*io.Reader)(nil)).Elem())
+	if err != nil {
+		t.Fatalf("Unable to resolve by type: %s", err)
+	}
+	if _, ok := reader.(*strings.Reader); !ok {
+		t.Fatalf("Unexpected value resolved by type")
+	}
+	if _, err = ResolveByType(container, reflect.TypeOf(0)); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Resolved value not assignable to the type")
+	}
+	if _, err = ResolveByType(container, reflect.TypeOf("")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Resolved unbound type")
+	}
+}
@@ -0,0 +1,26 @@
+//go:build godi_testing
+
+package godi
+
+import (
+	"errors"
+)
+
+// UnlockForTesting reverts Container.Lock, so tests can bind fakes to a
+// Container, which was already locked by the production wiring. It is
+// only compiled with the godi_testing build tag, keeping the escape hatch
+// out of production binaries. Frozen Containers can't be unlocked.
+func UnlockForTesting(c Container) error {
+	d, ok := c.(*defaultContainer)
+	if !ok {
+		return errors.New("container can not be unlocked")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return ErrFrozen
+	}
+	d.locked.Store(false)
+	d.plans.Store(nil)
+	return nil
+}
@@ -0,0 +1,29 @@
+//go:build godi_testing
+
+package godi
+
+import (
+	"testing"
+)
+
+func TestUnlockForTesting(t *testing.T) {
+	container := NewContainer()
+	container.MustBind("greeting", func(resolver ResolverFunc) any {
+		return "hello"
+	})
+	container.Lock()
+	if err := container.Bind("fake", nil); err == nil {
+		t.Fatalf("Bound to locked container")
+	}
+	if err := UnlockForTesting(container); err != nil {
+		t.Fatalf("Unable to unlock container: %s", err)
+	}
+	if err := container.Bind("fake", func(resolver ResolverFunc) any {
+		return "fake"
+	}); err != nil {
+		t.Fatalf("Unable to bind to unlocked container: %s", err)
+	}
+	if MustResolve[string]("fake", container.Resolver()) != "fake" {
+		t.Fatalf("Fake not resolved")
+	}
+}
@@ -0,0 +1,91 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WithStrictMode makes the Container reject bindings, which are likely
+// mistakes: empty or whitespace-only names, nil binders and names, which
+// differ from already bound names only by case.
+func WithStrictMode() ContainerOption {
+	return func(d *defaultContainer) {
+		d.strict = true
+	}
+}
+
+// WithNameValidator registers a function validating every name bound to
+// the Container, e.g. to enforce a naming convention like
+// "<module>.<service>". Names the validator reports an error for are
+// rejected.
+func WithNameValidator(validator func(name string) error) ContainerOption {
+	return func(d *defaultContainer) {
+		d.nameValidators = append(d.nameValidators, validator)
+	}
+}
+
+// WithReservedPrefixes reserves name prefixes, e.g. "godi." for the
+// bindings of the godi packages. Names starting with a reserved prefix
+// are rejected.
+func WithReservedPrefixes(prefixes ...string) ContainerOption {
+	return func(d *defaultContainer) {
+		d.reservedPrefixes = append(d.reservedPrefixes, prefixes...)
+	}
+}
+
+// validate checks a binding about to be bound under the given name.
+// The caller must hold the mutex of the Container.
+func (d *defaultContainer) validate(name string, b *binding) error {
+	if err := d.validateName(name); err != nil {
+		return err
+	}
+	if b.phase != "" && !slices.Contains(d.phases, b.phase) {
+		return errors.New(fmt.Sprintf("phase %s of %s service is not declared", b.phase, name))
+	}
+	if d.strict && b.factory == nil {
+		return errors.New(fmt.Sprintf("binder of %s service must not be nil", name))
+	}
+	return nil
+}
+
+// validateName checks a name about to be bound. The caller must hold
+// the mutex of the Container.
+func (d *defaultContainer) validateName(name string) error {
+	for _, prefix := range d.reservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return errors.New(fmt.Sprintf("service name %s uses reserved prefix %s", name, prefix))
+		}
+	}
+	for _, validator := range d.nameValidators {
+		if err := validator(name); err != nil {
+			return fmt.Errorf("invalid service name %s: %w", name, err)
+		}
+	}
+	if !d.strict {
+		return nil
+	}
+	if name == "" {
+		return errors.New("service name must not be empty")
+	}
+	if strings.TrimSpace(name) == "" {
+		return errors.New(fmt.Sprintf("service name %q must not consist of whitespace only", name))
+	}
+	var similar string
+	check := func(bound string) {
+		if bound != name && strings.EqualFold(bound, name) {
+			similar = bound
+		}
+	}
+	d.table.eachService(func(bound string, _ *binding) {
+		check(bound)
+	})
+	d.table.eachAlias(func(bound, _ string) {
+		check(bound)
+	})
+	if similar != "" {
+		return errors.New(fmt.Sprintf("service name %s differs from bound name %s only by case", name, similar))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package godi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithStrictMode(t *testing.T) {
+	binder := func(resolver ResolverFunc) any {
+		return "value"
+	}
+	container := NewContainer(WithStrictMode()).(*defaultContainer)
+	container.MustBind("userService", binder)
+	for name, err := range map[string]error{
+		"":            container.Bind("", binder),
+		"whitespace":  container.Bind("  \t", binder),
+		"nil binder":  container.Bind("nil", nil),
+		"nil pool":    container.BindPool("pool", 1, nil),
+		"case":        container.BindSingleton("UserService", binder),
+		"case alias":  container.Alias("userservice", "userService"),
+		"empty alias": container.Alias("", "userService"),
+	} {
+		if err == nil {
+			t.Fatalf("Strict container accepted %s", name)
+		}
+	}
+	if err := container.Alias("users", "userService"); err != nil {
+		t.Fatalf("Strict container rejected valid alias: %s", err)
+	}
+
+	lenient := NewContainer()
+	lenient.MustBind("userService", binder)
+	if err := lenient.Bind("UserService", binder); err != nil {
+		t.Fatalf("Lenient container rejected name: %s", err)
+	}
+}
+
+func TestWithNameValidator(t *testing.T) {
+	binder := func(resolver ResolverFunc) any {
+		return "value"
+	}
+	container := NewContainer(
+		WithReservedPrefixes("godi."),
+		WithNameValidator(func(name string) error {
+			if !strings.Contains(name, ".") {
+				return errors.New("expected <module>.<service>")
+			}
+			return nil
+		}),
+	)
+	if err := container.Bind("billing.invoices", binder); err != nil {
+		t.Fatalf("Unable to bind valid name: %s", err)
+	}
+	if err := container.Bind("invoices", binder); err == nil {
+		t.Fatalf("Bound name rejected by validator")
+	}
+	if err := container.Bind("godi.clock", binder); err == nil {
+		t.Fatalf("Bound name with reserved prefix")
+	}
+	if err := container.Alias("godi.invoices", "billing.invoices"); err == nil {
+		t.Fatalf("Bound alias with reserved prefix")
+	}
+	if err := container.(*defaultContainer).Clone().Bind("invoices", binder); err == nil {
+		t.Fatalf("Clone does not validate names")
+	}
+}
@@ -0,0 +1,52 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VersionName returns the name, under which the given version of a
+// dependency bound through Container.BindVersion can be resolved.
+func VersionName(name, version string) string {
+	return name + "@" + version
+}
+
+func (d *defaultContainer) BindVersion(name, version string, binder BinderFunc) error {
+	d.mu.RLock()
+	_, bound := d.table.service(name)
+	d.mu.RUnlock()
+	if bound {
+		return errors.New(fmt.Sprintf("service with name %s already bound", name))
+	}
+	if err := d.Bind(VersionName(name, version), binder); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.table.service(name); ok {
+		return errors.New(fmt.Sprintf("service with name %s already bound", name))
+	}
+	if _, ok := d.table.alias(name); !ok {
+		d.storeAlias(name, VersionName(name, version))
+	}
+	return nil
+}
+
+func (d *defaultContainer) SetDefaultVersion(name, version string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozen {
+		return fmt.Errorf("unable to version %s service: %w", name, ErrFrozen)
+	}
+	if d.locked.Load() {
+		return errors.New("service container locked. no more services can be bound")
+	}
+	if _, ok := d.table.service(name); ok {
+		return errors.New(fmt.Sprintf("service with name %s already bound", name))
+	}
+	if _, ok := d.table.service(VersionName(name, version)); !ok {
+		return errors.New(fmt.Sprintf("version %s of service %s not found in container", version, name))
+	}
+	d.storeAlias(name, VersionName(name, version))
+	return nil
+}
@@ -0,0 +1,48 @@
+package godi
+
+import (
+	"testing"
+)
+
+func TestDefaultContainer_BindVersion(t *testing.T) {
+	container := NewContainer()
+	for _, version := range []string{"v1", "v2"} {
+		version := version
+		err := container.BindVersion("codec", version, func(resolver ResolverFunc) any {
+			return version
+		})
+		if err != nil {
+			t.Fatalf("Unable to bind version %s of dependency %s: %s", version, "codec", err)
+		}
+	}
+	resolver := container.Resolver()
+	if v := MustResolve[string]("codec", resolver); v != "v1" {
+		t.Fatalf("Expected first version as default, got %s", v)
+	}
+	if v := MustResolve[string](VersionName("codec", "v2"), resolver); v != "v2" {
+		t.Fatalf("Expected requested version %s, got %s", "v2", v)
+	}
+
+	if err := container.SetDefaultVersion("codec", "v2"); err != nil {
+		t.Fatalf("Unable to change default version: %s", err)
+	}
+	if v := MustResolve[string]("codec", resolver); v != "v2" {
+		t.Fatalf("Expected changed default version %s, got %s", "v2", v)
+	}
+	if err := container.SetDefaultVersion("codec", "v3"); err == nil {
+		t.Fatalf("Set non existing version %s as default", "v3")
+	}
+	if err := container.BindVersion("codec", "v1", nil); err == nil {
+		t.Fatalf("Could override already existing version %s", "v1")
+	}
+
+	container.MustBind("parser", func(resolver ResolverFunc) any {
+		return "parser"
+	})
+	if err := container.BindVersion("parser", "v1", nil); err == nil {
+		t.Fatalf("Bound version of dependency %s bound as service", "parser")
+	}
+	if _, err := container.Resolver()(VersionName("parser", "v1")); err == nil {
+		t.Fatalf("Version of dependency %s bound as service bound", "parser")
+	}
+}
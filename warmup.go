@@ -0,0 +1,143 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// WarmupReport lists the outcome of Warmer.Warmup for every eager
+// singleton.
+type WarmupReport struct {
+	// Completed lists the singletons, which were constructed.
+	Completed []string
+	// Failed maps the singletons, whose construction failed, to the error.
+	Failed map[string]error
+	// Skipped lists the singletons, which were not constructed, as the
+	// context was done or an earlier phase failed before.
+	Skipped []string
+}
+
+// WithPhases declares the startup phases of the Container in the order
+// they are executed, like "infrastructure", "domain" and "transport".
+// Singletons are assigned to a phase through the Phase option.
+func WithPhases(phases ...string) ContainerOption {
+	return func(d *defaultContainer) {
+		d.phases = phases
+	}
+}
+
+// warmupPhase is a group of eager singletons constructed together.
+type warmupPhase struct {
+	name     string
+	names    []string
+	parallel bool
+}
+
+func (d *defaultContainer) Warmup(ctx context.Context) (WarmupReport, error) {
+	return d.warmup(ctx, d.eagerPhases())
+}
+
+// eagerPhases returns the sorted names of all eager singletons grouped by
+// their phases, in the order of the phases. Eager singletons without a
+// phase are constructed one after another, after all phases.
+func (d *defaultContainer) eagerPhases() []warmupPhase {
+	d.mu.RLock()
+	phased := make(map[string][]string, len(d.phases))
+	var unphased []string
+	d.table.eachService(func(name string, b *binding) {
+		switch {
+		case b.phase != "":
+			phased[b.phase] = append(phased[b.phase], name)
+		case b.eager:
+			unphased = append(unphased, name)
+		}
+	})
+	phases := make([]warmupPhase, 0, len(d.phases)+1)
+	for _, phase := range d.phases {
+		sort.Strings(phased[phase])
+		phases = append(phases, warmupPhase{name: phase, names: phased[phase], parallel: true})
+	}
+	d.mu.RUnlock()
+	sort.Strings(unphased)
+	return append(phases, warmupPhase{names: unphased})
+}
+
+// warmup constructs the singletons of the given phases within the given
+// context, one phase after another, until it is done or a phase failed.
+// Singletons left unconstructed are constructed lazily on their first
+// request.
+func (d *defaultContainer) warmup(ctx context.Context, phases []warmupPhase) (WarmupReport, error) {
+	report := WarmupReport{Failed: make(map[string]error)}
+	ctx = trusted(ctx)
+	var errs []error
+	for i, phase := range phases {
+		var err error
+		if phase.parallel {
+			err = d.warmupParallel(ctx, phase, &report)
+		} else {
+			err = d.warmupSequential(ctx, phase, &report)
+		}
+		if err == nil {
+			continue
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil || phase.parallel {
+			for _, rest := range phases[i+1:] {
+				report.Skipped = append(report.Skipped, rest.names...)
+			}
+			break
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// warmupSequential constructs the singletons of the phase one after
+// another, continuing past failed constructions.
+func (d *defaultContainer) warmupSequential(ctx context.Context, phase warmupPhase, report *WarmupReport) error {
+	var errs []error
+	for i, name := range phase.names {
+		if err := ctx.Err(); err != nil {
+			report.Skipped = append(report.Skipped, phase.names[i:]...)
+			errs = append(errs, fmt.Errorf("warmup interrupted: %w", err))
+			break
+		}
+		if _, err := d.resolve(ctx, name); err != nil {
+			report.Failed[name] = err
+			errs = append(errs, fmt.Errorf("unable to warm up %s service: %w", name, err))
+			continue
+		}
+		report.Completed = append(report.Completed, name)
+	}
+	return errors.Join(errs...)
+}
+
+// warmupParallel constructs the singletons of the phase concurrently.
+func (d *defaultContainer) warmupParallel(ctx context.Context, phase warmupPhase, report *WarmupReport) error {
+	if err := ctx.Err(); err != nil {
+		report.Skipped = append(report.Skipped, phase.names...)
+		return fmt.Errorf("warmup interrupted before %s phase: %w", phase.name, err)
+	}
+	failed := make([]error, len(phase.names))
+	var wg sync.WaitGroup
+	for i, name := range phase.names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			_, failed[i] = d.resolve(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+	var errs []error
+	for i, name := range phase.names {
+		if failed[i] != nil {
+			report.Failed[name] = failed[i]
+			errs = append(errs, fmt.Errorf("unable to warm up %s service in %s phase: %w", name, phase.name, failed[i]))
+			continue
+		}
+		report.Completed = append(report.Completed, name)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,107 @@
+package godi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDefaultContainer_Warmup(t *testing.T) {
+	container := NewContainer().(*defaultContainer)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var constructed []string
+	singleton := func(name string) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			constructed = append(constructed, name)
+			if name == "b" {
+				cancel()
+			}
+			return name
+		}
+	}
+	container.MustBindSingleton("a", singleton("a"), Eager())
+	container.MustBindSingleton("b", singleton("b"), Eager())
+	container.MustBindSingleton("c", singleton("c"), Eager())
+	container.MustBindSingleton("lazy", singleton("lazy"))
+	container.MustBindSingleton("failing", nil, Eager(), DependsOn("missing"))
+
+	report, err := container.Warmup(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Interrupted warmup not reported: %v", err)
+	}
+	if !reflect.DeepEqual(report.Completed, []string{"a", "b"}) || !reflect.DeepEqual(report.Skipped, []string{"c", "failing"}) {
+		t.Fatalf("Unexpected warmup report %+v", report)
+	}
+	if v := MustResolve[string]("c", container.Resolver()); v != "c" {
+		t.Fatalf("Skipped singleton %s not resolvable lazily", "c")
+	}
+
+	report, err = container.Warmup(context.Background())
+	if err == nil || report.Failed["failing"] == nil || len(report.Completed) != 3 {
+		t.Fatalf("Unexpected warmup report %+v with error %v", report, err)
+	}
+	if !reflect.DeepEqual(constructed, []string{"a", "b", "c"}) {
+		t.Fatalf("Singletons constructed more than once: %v", constructed)
+	}
+}
+
+func TestDefaultContainer_Warmup_Phases(t *testing.T) {
+	container := NewContainer(WithPhases("infrastructure", "domain", "transport")).(*defaultContainer)
+	var mu sync.Mutex
+	var order []string
+	singleton := func(name string) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return name
+		}
+	}
+	container.MustBindSingleton("server", singleton("server"), Phase("transport"))
+	container.MustBindSingleton("db", singleton("db"), Phase("infrastructure"))
+	container.MustBindSingleton("cache", singleton("cache"), Phase("infrastructure"))
+	container.MustBindSingleton("orders", singleton("orders"), Phase("domain"))
+	container.MustBindSingleton("metrics", singleton("metrics"), Eager())
+
+	report, err := container.Warmup(context.Background())
+	if err != nil {
+		t.Fatalf("Unable to warm up: %s", err)
+	}
+	if !reflect.DeepEqual(report.Completed, []string{"cache", "db", "orders", "server", "metrics"}) {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	if len(order) != 5 || order[2] != "orders" || order[3] != "server" || order[4] != "metrics" {
+		t.Fatalf("Phases not executed in order: %v", order)
+	}
+	if info, _ := container.Info("db"); info.Phase != "infrastructure" {
+		t.Fatalf("Phase not reported: %+v", info)
+	}
+
+	if err := container.BindSingleton("unknown", singleton("unknown"), Phase("startup")); err == nil {
+		t.Fatalf("Undeclared phase accepted")
+	}
+	if err := container.Bind("instanced", singleton("instanced"), Phase("domain")); err == nil {
+		t.Fatalf("Instanced binding assigned a phase")
+	}
+}
+
+func TestDefaultContainer_Warmup_PhaseFailure(t *testing.T) {
+	container := NewContainer(WithPhases("infrastructure", "transport")).(*defaultContainer)
+	container.MustBindSingleton("db", func(resolver ResolverFunc) any {
+		return "db"
+	}, Phase("infrastructure"), DependsOn("missing"))
+	container.MustBindSingleton("server", func(resolver ResolverFunc) any {
+		return "server"
+	}, Phase("transport"))
+
+	report, err := container.Warmup(context.Background())
+	if err == nil || report.Failed["db"] == nil {
+		t.Fatalf("Failed phase not reported: %v", err)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"server"}) || len(report.Completed) != 0 {
+		t.Fatalf("Later phase not skipped: %+v", report)
+	}
+}
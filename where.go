@@ -0,0 +1,32 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResolveWhere resolves all dependencies of the given Container, whose
+// BindingInfo matches the given predicate, mapped by their names.
+// Predicates may combine arbitrary metadata, like tags, qualifiers and
+// types, to discover plugins beyond simple groups. Private bindings of
+// Modules are never matched. The Container must implement Introspector.
+func ResolveWhere(c Container, predicate func(info BindingInfo) bool) (map[string]any, error) {
+	introspector, ok := AsIntrospector(c)
+	if !ok {
+		return nil, errors.New("unable to inspect bindings. container doesn't implement Introspector")
+	}
+	resolver := c.Resolver()
+	matches := make(map[string]any)
+	for _, name := range introspector.Names() {
+		info, err := introspector.Info(name)
+		if err != nil || info.Private || !predicate(info) {
+			continue
+		}
+		value, err := resolver(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve matching %s service: %w", name, err)
+		}
+		matches[name] = value
+	}
+	return matches, nil
+}
@@ -0,0 +1,34 @@
+package godi
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestResolveWhere(t *testing.T) {
+	container := NewContainer()
+	plugin := func(name string) BinderFunc {
+		return func(resolver ResolverFunc) any {
+			return name
+		}
+	}
+	container.MustBind("csv", plugin("csv"), Tags("exporter"), Qualifier("stable"))
+	container.MustBind("xml", plugin("xml"), Tags("exporter"), Qualifier("beta"))
+	container.MustBind("json", plugin("json"), Tags("exporter"), Qualifier("stable"))
+	container.MustBind("importer", plugin("importer"), Qualifier("stable"))
+
+	stable, err := ResolveWhere(container, func(info BindingInfo) bool {
+		return slices.Contains(info.Tags, "exporter") && info.Qualifier == "stable"
+	})
+	if err != nil {
+		t.Fatalf("Unable to resolve matching dependencies: %s", err)
+	}
+	expected := map[string]any{"csv#stable": "csv", "json#stable": "json"}
+	if !reflect.DeepEqual(stable, expected) {
+		t.Fatalf("Unexpected matches %v, expected %v", stable, expected)
+	}
+	if _, err := container.Resolver()("godi.container"); err == nil {
+		t.Fatalf("Resolved the container itself by name")
+	}
+}
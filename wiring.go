@@ -0,0 +1,203 @@
+package godi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// Wiring is a declarative description of the dependencies of a Container.
+// Every binding of a Wiring references a factory by its name, which allows
+// implementations to be selected through configuration files. A Wiring
+// is usually loaded from a JSON document with LoadWiring.
+//
+//	{
+//		"bindings": [
+//			{"name": "cache", "factory": "redis-cache", "singleton": true},
+//			{"name": "clock", "factory": "system-clock"}
+//		]
+//	}
+type Wiring struct {
+	Bindings []WiringBinding `json:"bindings"`
+}
+
+// WiringBinding describes a single dependency of a Wiring. Type optionally
+// declares the Go type of the dependency, like "*user.Service", and Import
+// the path of the package declaring it. Both are used by the godi command
+// to generate typed accessor functions.
+type WiringBinding struct {
+	Name      string `json:"name"`
+	Factory   string `json:"factory"`
+	Singleton bool   `json:"singleton,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Import    string `json:"import,omitempty"`
+}
+
+// Factories maps the factory names referenced by a Wiring
+// to the BinderFunc implementing them.
+type Factories map[string]BinderFunc
+
+// LoadWiring decodes a JSON encoded Wiring from the given reader.
+func LoadWiring(r io.Reader) (Wiring, error) {
+	var wiring Wiring
+	if err := json.NewDecoder(r).Decode(&wiring); err != nil {
+		return Wiring{}, fmt.Errorf("unable to decode wiring: %w", err)
+	}
+	return wiring, nil
+}
+
+// LoadWiringFile decodes a JSON encoded Wiring from the file at the given path.
+func LoadWiringFile(path string) (Wiring, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Wiring{}, err
+	}
+	defer file.Close()
+	return LoadWiring(file)
+}
+
+// LoadWiringFS decodes a JSON encoded Wiring from the named file of the
+// given file system. This allows wiring files to be embedded into the
+// binary through an embed.FS and selected at startup.
+//
+//	//go:embed wiring/*.json
+//	var wirings embed.FS
+//
+//	wiring, err := godi.LoadWiringFS(wirings, "wiring/"+service+".json")
+func LoadWiringFS(fsys fs.FS, name string) (Wiring, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return Wiring{}, err
+	}
+	defer file.Close()
+	return LoadWiring(file)
+}
+
+// Apply binds all dependencies of the Wiring to the given Container,
+// using the referenced binders of factories. An error is returned, if a
+// referenced factory does not exist or a dependency could not be bound.
+func (w Wiring) Apply(c Container, factories Factories) error {
+	for _, entry := range w.Bindings {
+		binder, ok := factories[entry.Factory]
+		if !ok {
+			return errors.New(fmt.Sprintf("factory %s of service %s not found", entry.Factory, entry.Name))
+		}
+		var err error
+		if entry.Singleton {
+			err = c.BindSingleton(entry.Name, binder)
+		} else {
+			err = c.Bind(entry.Name, binder)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WiringWatcher monitors a wiring file and applies changed bindings to a
+// Container, enabling configuration driven reconfiguration at runtime.
+//
+// Bindings referencing a different factory than before are replaced through
+// Swapper.Swap, which tears down already constructed singleton instances.
+// Changes of the fields only used for code generation, Type and Import,
+// leave the binding untouched.
+// Newly added bindings are bound to the Container. Errors occurring while
+// reloading the wiring file are passed to OnError, if set.
+type WiringWatcher struct {
+	Path      string
+	Factories Factories
+	Interval  time.Duration
+	OnError   func(err error)
+}
+
+// Watch polls the wiring file until the given context is done. The applied
+// Wiring describes the bindings already present in the Container and serves
+// as the baseline for detecting changes.
+func (w *WiringWatcher) Watch(ctx context.Context, c Container, applied Wiring) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	known := make(map[string]WiringBinding, len(applied.Bindings))
+	for _, entry := range applied.Bindings {
+		known[entry.Name] = entry
+	}
+	var content []byte
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		next, err := os.ReadFile(w.Path)
+		if err != nil {
+			w.reportError(err)
+			continue
+		}
+		if bytes.Equal(content, next) {
+			continue
+		}
+		content = next
+		wiring, err := LoadWiring(bytes.NewReader(next))
+		if err != nil {
+			w.reportError(err)
+			continue
+		}
+		w.apply(c, known, wiring)
+	}
+}
+
+// apply applies all changes of the next wiring to the given Container.
+// The known bindings are updated with every successfully applied change.
+func (w *WiringWatcher) apply(c Container, known map[string]WiringBinding, next Wiring) {
+	for _, entry := range next.Bindings {
+		old, exists := known[entry.Name]
+		if exists && old.Factory == entry.Factory && old.Singleton == entry.Singleton {
+			known[entry.Name] = entry
+			continue
+		}
+		var err error
+		switch binder, ok := w.Factories[entry.Factory]; {
+		case !ok:
+			err = errors.New(fmt.Sprintf("factory %s of service %s not found", entry.Factory, entry.Name))
+		case !exists && entry.Singleton:
+			err = c.BindSingleton(entry.Name, binder)
+		case !exists:
+			err = c.Bind(entry.Name, binder)
+		case old.Singleton != entry.Singleton:
+			err = errors.New(fmt.Sprintf("binding type of service %s can not be changed at runtime", entry.Name))
+		default:
+			err = swap(c, entry.Name, binder)
+		}
+		if err != nil {
+			w.reportError(err)
+			continue
+		}
+		known[entry.Name] = entry
+	}
+}
+
+// swap replaces the binder of the named dependency, if the Container
+// implements Swapper.
+func swap(c Container, name string, binder BinderFunc) error {
+	swapper, ok := AsSwapper(c)
+	if !ok {
+		return errors.New(fmt.Sprintf("unable to swap %s service. container doesn't implement Swapper", name))
+	}
+	return swapper.Swap(name, binder)
+}
+
+func (w *WiringWatcher) reportError(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
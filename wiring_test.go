@@ -0,0 +1,150 @@
+package godi
+
+import (
+	"context"
+	"embed"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFactories() Factories {
+	return Factories{
+		"one": func(resolver ResolverFunc) any {
+			return 1
+		},
+		"two": func(resolver ResolverFunc) any {
+			return 2
+		},
+		"closer": func(resolver ResolverFunc) any {
+			return &closeRecorder{}
+		},
+	}
+}
+
+func TestWiring_Apply(t *testing.T) {
+	wiring, err := LoadWiring(strings.NewReader(`{"bindings": [
+		{"name": "foo", "factory": "one"},
+		{"name": "bar", "factory": "two", "singleton": true}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unable to load wiring: %s", err)
+	}
+	container := NewContainer()
+	if err = wiring.Apply(container, testFactories()); err != nil {
+		t.Fatalf("Unable to apply wiring: %s", err)
+	}
+	if v := MustResolve[int]("foo", container.Resolver()); v != 1 {
+		t.Fatalf("Dependency %s has unexpected value. Expected %d got %d", "foo", 1, v)
+	}
+	if v := MustResolve[int]("bar", container.Resolver()); v != 2 {
+		t.Fatalf("Dependency %s has unexpected value. Expected %d got %d", "bar", 2, v)
+	}
+
+	missing := Wiring{Bindings: []WiringBinding{{Name: "baz", Factory: "three"}}}
+	if err = missing.Apply(container, testFactories()); err == nil {
+		t.Fatalf("Applied wiring with unknown factory %s", "three")
+	}
+}
+
+func TestWiringWatcher_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wiring.json")
+	write := func(content string) {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+			t.Fatalf("Unable to write wiring file: %s", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatalf("Unable to write wiring file: %s", err)
+		}
+	}
+	write(`{"bindings": [{"name": "foo", "factory": "closer", "singleton": true}]}`)
+
+	wiring, err := LoadWiringFile(path)
+	if err != nil {
+		t.Fatalf("Unable to load wiring file: %s", err)
+	}
+	container := NewContainer()
+	if err = wiring.Apply(container, testFactories()); err != nil {
+		t.Fatalf("Unable to apply wiring: %s", err)
+	}
+	container.Lock()
+	old := MustResolve[*closeRecorder]("foo", container.Resolver())
+
+	swapped := make(chan ChangeEvent, 1)
+	container.OnChange(func(event ChangeEvent) {
+		swapped <- event
+	})
+	watcher := WiringWatcher{
+		Path:      path,
+		Factories: testFactories(),
+		Interval:  time.Millisecond,
+		OnError: func(err error) {
+			t.Errorf("Unexpected watcher error: %s", err)
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watcher.Watch(ctx, container, wiring)
+	}()
+
+	write(`{"bindings": [{"name": "foo", "factory": "two", "singleton": true}]}`)
+	select {
+	case <-swapped:
+	case <-time.After(time.Second):
+		t.Fatalf("Changed wiring file not applied")
+	}
+	cancel()
+	<-done
+
+	if v := MustResolve[int]("foo", container.Resolver()); v != 2 {
+		t.Fatalf("Dependency %s has unexpected value. Expected %d got %d", "foo", 2, v)
+	}
+	if !old.closed {
+		t.Fatalf("Replaced singleton of %s not closed", "foo")
+	}
+}
+
+func TestWiringWatcher_apply_CodegenFields(t *testing.T) {
+	container := NewContainer()
+	container.MustBindSingleton("foo", testFactories()["closer"])
+	old := MustResolve[*closeRecorder]("foo", container.Resolver())
+	var changes []ChangeEvent
+	container.OnChange(func(event ChangeEvent) {
+		changes = append(changes, event)
+	})
+
+	watcher := WiringWatcher{Factories: testFactories()}
+	known := map[string]WiringBinding{"foo": {Name: "foo", Factory: "closer", Singleton: true}}
+	entry := WiringBinding{Name: "foo", Factory: "closer", Singleton: true, Type: "*Closer", Import: "example.com/closer"}
+	watcher.apply(container, known, Wiring{Bindings: []WiringBinding{entry}})
+	if len(changes) != 0 || old.closed {
+		t.Fatalf("Binding swapped for changed codegen fields: %v", changes)
+	}
+	if known["foo"] != entry {
+		t.Fatalf("Known binding not updated: %+v", known["foo"])
+	}
+}
+
+//go:embed testdata/wiring.json
+var embeddedWiring embed.FS
+
+func TestLoadWiringFS(t *testing.T) {
+	wiring, err := LoadWiringFS(embeddedWiring, "testdata/wiring.json")
+	if err != nil {
+		t.Fatalf("Unable to load embedded wiring: %s", err)
+	}
+	container := NewContainer()
+	if err = wiring.Apply(container, testFactories()); err != nil {
+		t.Fatalf("Unable to apply embedded wiring: %s", err)
+	}
+	if v := MustResolve[int]("bar", container.Resolver()); v != 2 {
+		t.Fatalf("Dependency %s has unexpected value. Expected %d got %d", "bar", 2, v)
+	}
+	if _, err = LoadWiringFS(embeddedWiring, "testdata/missing.json"); err == nil {
+		t.Fatalf("Loaded non existing wiring file")
+	}
+}